@@ -0,0 +1,121 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Authorized payment statuses.
+const (
+	AuthorizedPaymentStatusScheduled = "scheduled"
+	AuthorizedPaymentStatusProcessed = "processed"
+	AuthorizedPaymentStatusRecycling = "recycling"
+	AuthorizedPaymentStatusCancelled = "cancelled"
+)
+
+const _authorizedPaymentsPageSize = 50
+
+// AuthorizedPayment is one recurring charge (invoice) of a subscription.
+// Payment is the payment made for it; failed charges are retried while
+// Status is recycling.
+type AuthorizedPayment struct {
+	ID                int64      `json:"id"`
+	Type              string     `json:"type"`
+	PreapprovalID     string     `json:"preapproval_id"`
+	Status            string     `json:"status"`
+	Reason            string     `json:"reason"`
+	ExternalReference string     `json:"external_reference"`
+	CurrencyID        string     `json:"currency_id"`
+	TransactionAmount float64    `json:"transaction_amount"`
+	DebitDate         string     `json:"debit_date"`
+	NextRetryDate     string     `json:"next_retry_date"`
+	RetryAttempt      int        `json:"retry_attempt"`
+	DateCreated       string     `json:"date_created"`
+	LastModified      string     `json:"last_modified"`
+	Payment           PaymentReq `json:"payment"`
+}
+
+// AuthorizedPaymentSearch filters an authorized payments search. Empty fields
+// are left out of the query.
+type AuthorizedPaymentSearch struct {
+	PreapprovalID string
+	Limit         int
+	Offset        int
+}
+
+func (s AuthorizedPaymentSearch) query() string {
+	q := url.Values{}
+	if s.PreapprovalID != "" {
+		q.Set("preapproval_id", s.PreapprovalID)
+	}
+	if s.Limit > 0 {
+		q.Set("limit", strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		q.Set("offset", strconv.Itoa(s.Offset))
+	}
+	return q.Encode()
+}
+
+type AuthorizedPaymentSearchResponse struct {
+	Paging  Paging              `json:"paging"`
+	Results []AuthorizedPayment `json:"results"`
+}
+
+func (g *Gateway) GetAuthorizedPayment(accessToken string, id string) (AuthorizedPayment, error) {
+	return g.GetAuthorizedPaymentWithContext(context.Background(), accessToken, id)
+}
+
+func (g *Gateway) GetAuthorizedPaymentWithContext(ctx context.Context, accessToken string, id string) (payment AuthorizedPayment, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/authorized_payments/"+id, accessToken, nil, &payment)
+	return
+}
+
+func (g *Gateway) SearchAuthorizedPayments(accessToken string, search AuthorizedPaymentSearch) (AuthorizedPaymentSearchResponse, error) {
+	return g.SearchAuthorizedPaymentsWithContext(context.Background(), accessToken, search)
+}
+
+func (g *Gateway) SearchAuthorizedPaymentsWithContext(ctx context.Context, accessToken string, search AuthorizedPaymentSearch) (payments AuthorizedPaymentSearchResponse, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/authorized_payments/search?"+search.query(), accessToken, nil, &payments)
+	return
+}
+
+// GetSubscriptionCharges returns the whole charge history of a subscription,
+// going through every page of its authorized payments.
+func (g *Gateway) GetSubscriptionCharges(accessToken string, subscriptionID string) ([]AuthorizedPayment, error) {
+	return g.GetSubscriptionChargesWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (g *Gateway) GetSubscriptionChargesWithContext(ctx context.Context, accessToken string, subscriptionID string) ([]AuthorizedPayment, error) {
+	var charges, page []AuthorizedPayment
+
+	search := AuthorizedPaymentSearch{
+		PreapprovalID: subscriptionID,
+		Limit:         _authorizedPaymentsPageSize,
+	}
+	p := pager{
+		fetch: func(ctx context.Context, offset int) (int, int, error) {
+			search.Offset = offset
+			resp, err := g.SearchAuthorizedPaymentsWithContext(ctx, accessToken, search)
+			if err != nil {
+				return 0, 0, err
+			}
+			page = resp.Results
+			return len(resp.Results), resp.Paging.Total, nil
+		},
+	}
+	for {
+		i, ok := p.next(ctx)
+		if !ok {
+			break
+		}
+		charges = append(charges, page[i])
+	}
+
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return charges, nil
+}
@@ -0,0 +1,98 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_GetAuthorizedPayment(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 6114264375, "type": "scheduled", "preapproval_id": "SUBSCRIPTION_ID", "status": "processed", "transaction_amount": 10, "currency_id": "BRL", "debit_date": "2022-01-10T10:00:00.000-04:00", "retry_attempt": 1, "payment": {"id": 19951521071, "status": "approved", "status_detail": "accredited"}}`))),
+    }
+    // When
+    payment, err := g.GetAuthorizedPayment("MY_ACCESS_TOKEN", "6114264375")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, int64(6114264375), payment.ID)
+    require.Equal(t, "SUBSCRIPTION_ID", payment.PreapprovalID)
+    require.Equal(t, AuthorizedPaymentStatusProcessed, payment.Status)
+    require.Equal(t, 19951521071, payment.Payment.Id)
+    require.Equal(t, PaymentStatusApproved, payment.Payment.Status)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/authorized_payments/6114264375", c.req.URL.Path)
+}
+
+func TestGateway_SearchAuthorizedPayments(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"offset": 10, "limit": 10, "total": 11}, "results": [{"id": 1, "status": "recycling", "payment": {"id": 2, "status": "rejected"}}]}`))),
+    }
+    // When
+    payments, err := g.SearchAuthorizedPayments("MY_ACCESS_TOKEN", AuthorizedPaymentSearch{
+        PreapprovalID: "SUBSCRIPTION_ID",
+        Limit:         10,
+        Offset:        10,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 11, payments.Paging.Total)
+    require.Equal(t, AuthorizedPaymentStatusRecycling, payments.Results[0].Status)
+    require.Equal(t, PaymentStatusRejected, payments.Results[0].Payment.Status)
+    require.Equal(t, "/authorized_payments/search", c.req.URL.Path)
+    require.Equal(t, "limit=10&offset=10&preapproval_id=SUBSCRIPTION_ID", c.req.URL.RawQuery)
+}
+
+func TestGateway_GetSubscriptionCharges(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, `{"paging": {"offset": 0, "limit": 50, "total": 3}, "results": [{"id": 1}, {"id": 2}]}`),
+            newResponse(http.StatusOK, `{"paging": {"offset": 2, "limit": 50, "total": 3}, "results": [{"id": 3}]}`),
+        },
+    }
+    g := &Gateway{Client: c}
+
+    // When
+    charges, err := g.GetSubscriptionCharges("MY_ACCESS_TOKEN", "SUBSCRIPTION_ID")
+
+    // Then
+    require.NoError(t, err)
+    require.Len(t, charges, 3)
+    require.Equal(t, int64(3), charges[2].ID)
+    require.Equal(t, 2, c.calls)
+    require.Equal(t, "limit=50&preapproval_id=SUBSCRIPTION_ID", c.reqs[0].URL.RawQuery)
+    require.Equal(t, "limit=50&offset=2&preapproval_id=SUBSCRIPTION_ID", c.reqs[1].URL.RawQuery)
+}
+
+func TestGateway_GetSubscriptionCharges_MercadoPagoError(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, `{"paging": {"offset": 0, "limit": 50, "total": 60}, "results": [{"id": 1}]}`),
+            newResponse(http.StatusUnauthorized, `{"message": "invalid access token", "error": "unauthorized"}`),
+        },
+    }
+    g := &Gateway{Client: c}
+
+    // When
+    charges, err := g.GetSubscriptionCharges("MY_ACCESS_TOKEN", "SUBSCRIPTION_ID")
+
+    // Then
+    require.Error(t, err)
+    require.True(t, IsUnauthorized(err))
+    require.Nil(t, charges)
+}
@@ -0,0 +1,64 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+)
+
+// NewCardToken is the body of CreateCardToken. A new card sends its number,
+// expiration, security code and cardholder; a card saved for a customer sends
+// its Card_id and the security code entered by the payer.
+type NewCardToken struct {
+	Card_id          string      `json:"card_id,omitempty"`
+	Card_number      string      `json:"card_number,omitempty"`
+	Expiration_month int         `json:"expiration_month,omitempty"`
+	Expiration_year  int         `json:"expiration_year,omitempty"`
+	Security_code    string      `json:"security_code,omitempty"`
+	Cardholder       *Cardholder `json:"cardholder,omitempty"`
+}
+
+// CardToken is a single-use card token, sent as NewPayment.Token or to
+// CreateCustomerCard.
+type CardToken struct {
+	Id                   string     `json:"id"`
+	Public_key           string     `json:"public_key"`
+	Card_id              string     `json:"card_id"`
+	Status               string     `json:"status"`
+	First_six_digits     string     `json:"first_six_digits"`
+	Last_four_digits     string     `json:"last_four_digits"`
+	Card_number_length   int        `json:"card_number_length"`
+	Security_code_length int        `json:"security_code_length"`
+	Expiration_month     int        `json:"expiration_month"`
+	Expiration_year      int        `json:"expiration_year"`
+	Cardholder           Cardholder `json:"cardholder"`
+	Luhn_validation      bool       `json:"luhn_validation"`
+	Live_mode            bool       `json:"live_mode"`
+	Require_esc          bool       `json:"require_esc"`
+	Date_created         string     `json:"date_created"`
+	Date_last_updated    string     `json:"date_last_updated"`
+	Date_due             string     `json:"date_due"`
+}
+
+// CreateCardToken tokenizes a card server side. Only PCI compliant systems
+// may handle raw card data; browsers and apps tokenize with the public key.
+func (g *Gateway) CreateCardToken(accessToken string, card NewCardToken) (CardToken, error) {
+	return g.CreateCardTokenWithContext(context.Background(), accessToken, card)
+}
+
+func (g *Gateway) CreateCardTokenWithContext(ctx context.Context, accessToken string, card NewCardToken) (token CardToken, err error) {
+	err = g.doJSON(ctx, http.MethodPost, "/v1/card_tokens", accessToken, card, &token)
+	return
+}
+
+// CreateSavedCardToken tokenizes a card saved for a customer again, with the
+// security code entered by the payer, to pay with it.
+func (g *Gateway) CreateSavedCardToken(accessToken string, cardID string, securityCode string) (CardToken, error) {
+	return g.CreateSavedCardTokenWithContext(context.Background(), accessToken, cardID, securityCode)
+}
+
+func (g *Gateway) CreateSavedCardTokenWithContext(ctx context.Context, accessToken string, cardID string, securityCode string) (CardToken, error) {
+	return g.CreateCardTokenWithContext(ctx, accessToken, NewCardToken{
+		Card_id:       cardID,
+		Security_code: securityCode,
+	})
+}
@@ -0,0 +1,123 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreateCardToken(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "ff8080814c11e237014c1ff593b57b4d", "status": "active", "first_six_digits": "423564", "last_four_digits": "5682", "expiration_month": 11, "expiration_year": 2030, "security_code_length": 3, "luhn_validation": true, "live_mode": false, "cardholder": {"name": "APRO", "identification": {"type": "CPF", "number": "12345678909"}}}`))),
+    }
+    card, ok := FindTestCard("MLB", "visa")
+    require.True(t, ok)
+
+    // When
+    token, err := g.CreateCardToken("MY_ACCESS_TOKEN", card.NewCardToken(TestCardApproved, Identification{Type: "CPF", Number: "12345678909"}))
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "ff8080814c11e237014c1ff593b57b4d", token.Id)
+    require.Equal(t, "5682", token.Last_four_digits)
+    require.Equal(t, "APRO", token.Cardholder.Name)
+    require.True(t, token.Luhn_validation)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/v1/card_tokens", c.req.URL.Path)
+    require.Equal(t, "Bearer MY_ACCESS_TOKEN", c.req.Header.Get("Authorization"))
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"card_number": "4235647728025682", "expiration_month": 11, "expiration_year": 2030, "security_code": "123", "cardholder": {"name": "APRO", "identification": {"type": "CPF", "number": "12345678909"}}}`, string(b))
+}
+
+func TestGateway_CreateSavedCardToken(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "SAVED_CARD_TOKEN", "card_id": "CARD_ID", "status": "active"}`))),
+    }
+    // When
+    token, err := g.CreateSavedCardToken("MY_ACCESS_TOKEN", "CARD_ID", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "SAVED_CARD_TOKEN", token.Id)
+    require.Equal(t, "CARD_ID", token.Card_id)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"card_id": "CARD_ID", "security_code": "123"}`, string(b))
+}
+
+func TestGateway_CreateCardToken_MercadoPagoError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "400",
+        StatusCode: 400,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message": "invalid parameters", "error": "bad_request", "status": 400, "cause": [{"code": "E301", "description": "invalid card_number_id"}]}`))),
+    }
+    // When
+    _, err := g.CreateCardToken("MY_ACCESS_TOKEN", NewCardToken{Card_number: "1234"})
+
+    // Then
+    require.True(t, IsValidation(err))
+    e, ok := AsError(err)
+    require.True(t, ok)
+    require.True(t, e.HasCause("E301"))
+}
+
+func TestTestCards(t *testing.T) {
+    sites := []string{"MLA", "MLB", "MLC", "MCO", "MLM", "MPE", "MLU"}
+
+    for _, site := range sites {
+        t.Run(site, func(t *testing.T) {
+            // When
+            cards := TestCards(site)
+
+            // Then
+            require.NotEmpty(t, cards)
+            for _, c := range cards {
+                require.Equal(t, site, c.Site_id)
+                require.True(t, luhnValid(c.Card_number), c.Card_number)
+            }
+        })
+    }
+}
+
+func TestFindTestCard_Unknown(t *testing.T) {
+    // When
+    _, ok := FindTestCard("MLB", "diners")
+
+    // Then
+    require.False(t, ok)
+}
+
+func luhnValid(number string) bool {
+    sum := 0
+    double := false
+    for i := len(number) - 1; i >= 0; i-- {
+        d := int(number[i] - '0')
+        if double {
+            d *= 2
+            if d > 9 {
+                d -= 9
+            }
+        }
+        sum += d
+        double = !double
+    }
+    return sum%10 == 0
+}
@@ -2,13 +2,14 @@ package mercadopago
 
 import (
 	"bytes"
+	"context"
+	"io"
 	"io/ioutil"
 
-	// "context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 	// "github.com/mercadopago/sdk-go/pkg/config"
 	// "github.com/mercadopago/sdk-go/pkg/preapproval"
 )
@@ -21,6 +22,11 @@ type Client interface {
 
 type Gateway struct {
 	Client Client
+
+	baseURL string
+	authURL string
+	headers http.Header
+	timeout time.Duration
 }
 
 func NewClientGateway(client Client) *Gateway {
@@ -29,61 +35,102 @@ func NewClientGateway(client Client) *Gateway {
 	}
 }
 
-type PaymentReq struct {
-	Id                 int    `json:"id"`
-	Client_id          string `json:"client_id"`
-	Collector_id       int    `json:"collector_id"`
-	Currency_id        string `json:"currency_id"`
-	Payment_type_id    string `json:"payment_type_id"`
-	Date_approved      string `json:"date_approved"`
-	External_reference string `json:"external_reference"`
-	Installments       int    `json:"installments"`
-	Order              struct {
-		Id   string `json:"id"`
-		Type string `json:"type"`
-	} `json:"order"`
-	Payer struct {
-		Email          string `json:"email"`
-		Identification struct {
-			Type   string `json:"type"`
-			Number string `json:"number"`
-		} `json:"identification"`
-	} `json:"payer"`
-	Transaction_amount float32 `json:"transaction_amount"`
-	Captured           bool    `json:"captured"`
-	Status             string  `json:"status"`
-}
-
-type PaymentReqSearch struct {
-	Results []struct {
-		Id                 int    `json:"id"`
-		External_reference string `json:"external_reference"`
-		Collector_id       int    `json:"collector_id"`
-		Currency_id        string `json:"currency_id"`
-		Payment_type_id    string `json:"payment_type_id"`
-		Payer              struct {
-			Email          string `json:"email"`
-			Identification struct {
-				Type   string `json:"type"`
-				Number string `json:"number"`
-			} `json:"identification"`
-		} `json:"payer"`
-		Status string `json:"status"`
-	} `json:"results"`
-}
-
-/*type MerchantOrders struct {
-	Elements []struct {
-		Id 					int `json:"id"`
-		External_reference 	string `json:"external_reference"`
-		Preference_id 		string `json:"preference_id"`
-		Payments	[]struct {
-			Id 		string `json:"collector_id"`
-			Status 	string `json:"currency_id"`
-		} `json:"payments"`
-		Status 				string `json:"status"`
-	} `json:"elements"`
-}*/
+// url resolves path against the configured base URL.
+func (g *Gateway) url(path string) string {
+	if g.baseURL == "" {
+		return _baseURL + path
+	}
+	return g.baseURL + path
+}
+
+// newRequest builds a request for path against the MercadoPago API, encoding
+// in (when not nil) as its JSON body.
+func (g *Gateway) newRequest(ctx context.Context, method string, path string, in interface{}) (*http.Request, error) {
+	var b []byte
+	var reqBody io.Reader
+	if in != nil {
+		var err error
+		b, err = json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.url(path), reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range g.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	setIdempotencyKey(req, b)
+
+	return req, nil
+}
+
+// send executes req and decodes the response body into out (when not nil).
+// The gateway timeout applies unless the request context already carries a
+// deadline.
+func (g *Gateway) send(req *http.Request, out interface{}) error {
+	if _, ok := req.Context().Deadline(); !ok && g.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), g.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(body, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// doJSON sends a request authenticated with accessToken to path, encoding in
+// (when not nil) as the JSON body and decoding the response into out (when
+// not nil).
+func (g *Gateway) doJSON(ctx context.Context, method string, path string, accessToken string, in interface{}, out interface{}) error {
+	req, err := g.newRequest(ctx, method, path, in)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+
+	return g.send(req, out)
+}
+
+// PaymentReq is the payment model returned by the first versions of the SDK.
+//
+// Deprecated: use Payment.
+type PaymentReq = Payment
+
+// PaymentReqSearch is the payments search response of the first versions of
+// the SDK.
+//
+// Deprecated: use PaymentSearchResponse.
+type PaymentReqSearch = PaymentSearchResponse
 
 /*type SubscriptionReqSearch struct {
 	Results []Subscription `json:"results"`
@@ -109,12 +156,75 @@ type SubscriptionSearchResponse struct {
 	Results []SubscriptionResult `json:"results"`
 }
 
-type SubscriptionPaging struct {
+// Paging is the paging block of MercadoPago search responses.
+type Paging struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
 	Total  int `json:"total"`
 }
 
+type SubscriptionPaging = Paging
+
+// pager walks the pages of a search by offset for the search iterators and
+// GetSubscriptionCharges. fetch gets the page at offset and returns its length
+// and the number of results.
+type pager struct {
+	offset  int
+	fetch   func(ctx context.Context, offset int) (n int, total int, err error)
+	size    int
+	index   int
+	total   int
+	started bool
+	done    bool
+	err     error
+}
+
+// next advances to the next result, fetching the next page when the current
+// one is used up, and returns its index in the page last fetched. It reports
+// false once all of them were read or a page couldn't be fetched.
+func (p *pager) next(ctx context.Context) (int, bool) {
+	if p.done {
+		return 0, false
+	}
+
+	if p.index >= p.size {
+		if p.started && p.offset >= p.total {
+			p.done = true
+			return 0, false
+		}
+
+		n, total, err := p.fetch(ctx, p.offset)
+		if err != nil {
+			p.err = err
+			p.done = true
+			return 0, false
+		}
+
+		p.started = true
+		p.size, p.index, p.total = n, 0, total
+		p.offset += n
+
+		if n == 0 {
+			p.done = true
+			return 0, false
+		}
+	}
+
+	p.index++
+	return p.index - 1, true
+}
+
+// Total returns the number of results matching the search, known once Next
+// was called.
+func (p *pager) Total() int {
+	return p.total
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *pager) Err() error {
+	return p.err
+}
+
 type SubscriptionResult struct {
 	ID                string                 `json:"id"`
 	Status            string                 `json:"status"`
@@ -136,6 +246,8 @@ type SubscriptionResult struct {
 	PayerFirstName  string `json:"payer_first_name"`
 	PayerLastName   string `json:"payer_last_name"`
 	SubscriptionID  string `json:"subscription_id"`
+	// PreapprovalPlanID is the plan of subscriptions created from one.
+	PreapprovalPlanID string `json:"preapproval_plan_id"`
 }
 
 type SubscriptionSummarized struct {
@@ -150,302 +262,162 @@ type SubscriptionSummarized struct {
 	LastChargedAmount float64 `json:"last_charged_amount"`
 }
 
+// AutoRecurring is the billing setup of a subscription. Fields left empty are
+// omitted, so it can also be sent in create and update requests.
 type AutoRecurring struct {
-	Frequency         int     `json:"frequency"`
-	FrequencyType     string  `json:"frequency_type"`
-	TransactionAmount float64 `json:"transaction_amount"`
-	CurrencyID        string  `json:"currency_id"`
+	Frequency         int     `json:"frequency,omitempty"`
+	FrequencyType     string  `json:"frequency_type,omitempty"`
+	TransactionAmount float64 `json:"transaction_amount,omitempty"`
+	CurrencyID        string  `json:"currency_id,omitempty"`
 	// StartDate         time.Time `json:"start_date"`
-	StartDate string `json:"start_date"`
+	StartDate string `json:"start_date,omitempty"`
 	// EndDate           time.Time `json:"end_date"`
-	EndDate string `json:"end_date"`
+	EndDate string `json:"end_date,omitempty"`
+	// Repetitions limits the number of charges; zero charges until cancelled.
+	Repetitions int `json:"repetitions,omitempty"`
+	// BillingDay is the day of the month charges are made on, for monthly
+	// plans. With BillingDayProportional the first charge is proportional to
+	// the days left until it.
+	BillingDay             int        `json:"billing_day,omitempty"`
+	BillingDayProportional bool       `json:"billing_day_proportional,omitempty"`
+	FreeTrial              *FreeTrial `json:"free_trial,omitempty"`
+}
+
+// FreeTrial delays the first charge of a subscription, e.g. by 7 days.
+type FreeTrial struct {
+	Frequency     int    `json:"frequency"`
+	FrequencyType string `json:"frequency_type"`
 }
 
 func (g *Gateway) GetAccessToken(credentials Credentials) (string, error) {
-	path := &url.Values{}
-	path.Add("client_id", credentials.ClientID)
-	path.Add("client_secret", credentials.ClientSecret)
-	path.Add("grant_type", "client_credentials")
-	queryParams := path.Encode()
+	return g.GetAccessTokenWithContext(context.Background(), credentials)
+}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s%s", _baseURL, "/oauth/token?", queryParams), nil)
+func (g *Gateway) GetAccessTokenWithContext(ctx context.Context, credentials Credentials) (string, error) {
+	token, err := g.GetTokenWithContext(ctx, credentials)
 	if err != nil {
 		return "", err
 	}
 
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return "", err
-	}
+	return token.AccessToken, nil
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
+// GetToken requests a client_credentials token, keeping its expiry, scope
+// and user.
+func (g *Gateway) GetToken(credentials Credentials) (Token, error) {
+	return g.GetTokenWithContext(context.Background(), credentials)
+}
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		return "", NewError(string(body), resp.StatusCode)
-	}
+func (g *Gateway) GetTokenWithContext(ctx context.Context, credentials Credentials) (Token, error) {
+	path := &url.Values{}
+	path.Add("client_id", credentials.ClientID)
+	path.Add("client_secret", credentials.ClientSecret)
+	path.Add("grant_type", "client_credentials")
+	queryParams := path.Encode()
 
-	var r struct {
-		AccessToken string `json:"access_token"`
+	req, err := g.newRequest(ctx, http.MethodPost, "/oauth/token?"+queryParams, nil)
+	if err != nil {
+		return Token{}, err
 	}
 
-	if err := json.Unmarshal(body, &r); err != nil {
-		return "", err
-	}
+	return g.sendTokenRequest(req)
+}
 
-	return r.AccessToken, nil
+// CreatePreference creates a checkout preference and returns it, with the
+// Init_point and Sandbox_init_point to send the payer to.
+func (g *Gateway) CreatePreference(accessToken string, preference NewPreference) (Preference, error) {
+	return g.CreatePreferenceWithContext(context.Background(), accessToken, preference)
 }
 
-func (g *Gateway) CreatePreference(accessToken string, preference NewPreference) (string, string, error) {
+func (g *Gateway) CreatePreferenceWithContext(ctx context.Context, accessToken string, preference NewPreference) (Preference, error) {
 	queryValues := &url.Values{}
 	queryValues.Add("access_token", accessToken)
 	queryParams := queryValues.Encode()
 
-	b, err := json.Marshal(preference)
-	if err != nil {
-		return "", "", err
-	}
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s%s", _baseURL, "/checkout/preferences?", queryParams), bytes.NewReader(b))
-	if err != nil {
-		return "", "", err
-	}
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return "", "", err
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
+	req, err := g.newRequest(ctx, http.MethodPost, "/checkout/preferences?"+queryParams, preference)
 	if err != nil {
-		return "", "", err
+		return Preference{}, err
 	}
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		return "", "", NewError(string(body), resp.StatusCode)
+	var created Preference
+	if err := g.send(req, &created); err != nil {
+		return Preference{}, err
 	}
 
-	var r struct {
-		Id string `json:"id"`
-		//Collector_id int `json:"collector_id"`
-		Client_id   string `json:"client_id"`
-		CheckoutURL string `json:"init_point"`
-	}
-
-	if err := json.Unmarshal(body, &r); err != nil {
-		return "", "", err
-	}
-
-	return r.Id, r.CheckoutURL, nil
+	return created, nil
 }
 
+// GetCheckoutPreferences returns the amount of a preference, truncated to an
+// int.
+//
+// Deprecated: use GetPreference.
 func (g *Gateway) GetCheckoutPreferences(accessToken string, id string) (int, error) {
-	///queryValues := &url.Values{}
-	///queryValues.Add("limit", "1")
-	///queryValues.Add("offset", "0")
-	///queryValues.Add("access_token", accessToken)
-	///queryValues.Add("id", id)
-
-	///queryParams := queryValues.Encode()
-
-	req, err := http.NewRequest("GET", _baseURL+"/checkout/preferences/"+id, nil)
-	if err != nil {
-		return 0, err
-	}
-
-	req.Header.Add("Authorization", "Bearer "+accessToken)
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return 0, err
-	}
+	return g.GetCheckoutPreferencesWithContext(context.Background(), accessToken, id)
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+// Deprecated: use GetPreferenceWithContext.
+func (g *Gateway) GetCheckoutPreferencesWithContext(ctx context.Context, accessToken string, id string) (int, error) {
+	preference, err := g.GetPreferenceWithContext(ctx, accessToken, id)
 	if err != nil {
 		return 0, err
 	}
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		return 0, NewError(string(body), resp.StatusCode)
-	}
-
-	var r struct {
-		Id                 string `json:"id"`
-		Client_id          string `json:"client_id"`
-		Collector_id       int    `json:"collector_id"`
-		External_reference string `json:"external_reference"`
-		Total_amount       int    `json:"total_amount"`
-	}
-
-	if err := json.Unmarshal(body, &r); err != nil {
-		return 0, err
-	}
-
-	return r.Total_amount, nil
+	return int(preference.Total()), nil
 }
 
-func (g *Gateway) GetPayments(accessToken string, id string) (payment PaymentReq, err error) {
-	///queryValues := &url.Values{}
-	///queryValues.Add("limit", "1")
-	///queryValues.Add("offset", "0")
-	///queryValues.Add("access_token", accessToken)
-	///queryValues.Add("id", id)
-
-	///queryParams := queryValues.Encode()
-
-	req, err := http.NewRequest("GET", _baseURL+"/v1/payments/"+id, nil)
-	if err != nil {
-		return
-	}
-
-	req.Header.Add("Authorization", "Bearer "+accessToken)
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return
-	}
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		err = NewError(string(body), resp.StatusCode)
-		return
-	}
-
-	//r := PaymentReq{}
-	if err := json.Unmarshal(body, &payment); err != nil {
-		return payment, err
-	}
-
-	return payment, nil
+func (g *Gateway) GetPayments(accessToken string, id string) (Payment, error) {
+	return g.GetPaymentsWithContext(context.Background(), accessToken, id)
 }
 
-func (g *Gateway) GetPaymentsSearch(accessToken string, external_reference string) (payment PaymentReqSearch, err error) {
-	///queryValues := &url.Values{}
-	///queryValues.Add("limit", "1")
-	///queryValues.Add("offset", "0")
-	///queryValues.Add("access_token", accessToken)
-	///queryValues.Add("id", id)
-
-	///queryParams := queryValues.Encode()
-
-	//https://api.mercadopago.com/v1/payments/search?sort=date_created&criteria=desc&external_reference=ID_a9XY6Qd+aKTswbX2sdZQ/B0Mzs8pSWnzynl/CR1Ek5Y
-
-	req, err := http.NewRequest("GET", _baseURL+"/v1/payments/search?sort=date_created&criteria=desc&external_reference="+external_reference, nil)
-	// fmt.Println(_baseURL + "/v1/payments/search?sort=date_created&criteria=desc&external_reference=" + external_reference)
-	// fmt.Println(req)
-	// fmt.Println(err)
-	if err != nil {
-		return
-	}
-
-	req.Header.Add("Authorization", "Bearer "+accessToken)
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return
-	}
+func (g *Gateway) GetPaymentsWithContext(ctx context.Context, accessToken string, id string) (payment Payment, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/payments/"+id, accessToken, nil, &payment)
+	return
+}
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		err = NewError(string(body), resp.StatusCode)
-	}
+// GetPaymentsSearch returns the payments of an external reference, newest
+// first.
+//
+// Deprecated: use SearchPayments.
+func (g *Gateway) GetPaymentsSearch(accessToken string, external_reference string) (PaymentReqSearch, error) {
+	return g.GetPaymentsSearchWithContext(context.Background(), accessToken, external_reference)
+}
 
-	///r := PaymentReqSearch{}
-	if err = json.Unmarshal(body, &payment); err != nil {
-		return
-	}
+// Deprecated: use SearchPaymentsWithContext.
+func (g *Gateway) GetPaymentsSearchWithContext(ctx context.Context, accessToken string, external_reference string) (PaymentReqSearch, error) {
+	return g.SearchPaymentsWithContext(ctx, accessToken, PaymentSearch{
+		External_reference: external_reference,
+		Sort:               "date_created",
+		Criteria:           "desc",
+	})
+}
 
-	return
+func (g *Gateway) GetSubscriptionsSearch(accessToken string, external_reference string) (SubscriptionSearchResponse, error) {
+	return g.GetSubscriptionsSearchWithContext(context.Background(), accessToken, external_reference)
 }
 
-func (g *Gateway) GetSubscriptionsSearch(
+func (g *Gateway) GetSubscriptionsSearchWithContext(
+	ctx context.Context,
 	accessToken string,
 	external_reference string,
-) (subscription SubscriptionSearchResponse, err error) {
-
-	url := _baseURL + "/preapproval/search" +
-		"?sort=date_created:desc" +
-		"&q=" + external_reference
-
-	req, err := http.NewRequest("GET", url, nil)
-	// fmt.Println(url)
-	// fmt.Println(req)
-	// fmt.Println(err)
-	if err != nil {
-		return
-	}
-
-	req.Header.Add("Authorization", "Bearer "+accessToken)
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return
-	}
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		err = NewError(string(body), resp.StatusCode)
-		return
-	}
+) (SubscriptionSearchResponse, error) {
 
-	if err = json.Unmarshal(body, &subscription); err != nil {
-		return
-	}
+	return g.SearchSubscriptionsWithContext(ctx, accessToken, SubscriptionSearch{
+		Q:    external_reference,
+		Sort: "date_created:desc",
+	})
+}
 
-	return
+func (g *Gateway) GetSubscriptionByID(accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return g.GetSubscriptionByIDWithContext(context.Background(), accessToken, subscriptionID)
 }
 
-func (g *Gateway) GetSubscriptionByID(
+func (g *Gateway) GetSubscriptionByIDWithContext(
+	ctx context.Context,
 	accessToken string,
 	subscriptionID string,
 ) (subscription SubscriptionResult, err error) {
 
-	url := _baseURL + "/preapproval/" + subscriptionID
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return
-	}
-
-	req.Header.Add("Authorization", "Bearer "+accessToken)
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return
-	}
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		err = NewError(string(body), resp.StatusCode)
-		return
-	}
-
-	var result SubscriptionResult
-	if err = json.Unmarshal(body, &result); err != nil {
-		return
-	}
-
-	subscription = result
+	err = g.doJSON(ctx, http.MethodGet, "/preapproval/"+subscriptionID, accessToken, nil, &subscription)
 	return
 }
 
@@ -475,87 +447,19 @@ func GetSubscriptionsSearch(
 }
 */
 
-/*
-func (g *Gateway) GetMerchantOrders(accessToken string, order_id string) (merchantOrder MerchantOrders, err error) {
-    ///queryValues := &url.Values{}
-    ///queryValues.Add("limit", "1")
-    ///queryValues.Add("offset", "0")
-    ///queryValues.Add("access_token", accessToken)
-    ///queryValues.Add("id", id)
-
-    ///queryParams := queryValues.Encode()
-
-    //https://api.mercadopago.com/v1/payments/search?sort=date_created&criteria=desc&external_reference=ID_a9XY6Qd+aKTswbX2sdZQ/B0Mzs8pSWnzynl/CR1Ek5Y
-
-    req, err := http.NewRequest("GET", _baseURL + "/v1/merchant_orders?preference_id="+order_id, nil)
-    if err != nil {
-        return
-    }
-
-    req.Header.Add("Authorization", "Bearer " + accessToken)
-
-    resp, err := g.Client.Do(req)
-    if err != nil {
-        return
-    }
-
-    body, err := ioutil.ReadAll(resp.Body)
-    fmt.Println(string(body))
-    fmt.Println(err)
-    if err != nil {
-        return
-	}
-
-    if resp.StatusCode >= http.StatusBadRequest {
-        err = NewError(string(body), resp.StatusCode)
-    }
-
-    ///r := PaymentReqSearch{}
-    if err = json.Unmarshal(body, &merchantOrder); err != nil {
-		return
-    }
-
-    return
-}
-*/
-
+// GetTotalPayments returns the number of payments with the given status.
 func (g *Gateway) GetTotalPayments(accessToken string, status string) (int, error) {
-	queryValues := &url.Values{}
-	queryValues.Add("limit", "1")
-	queryValues.Add("offset", "0")
-	queryValues.Add("access_token", accessToken)
-	queryValues.Add("status", status)
-
-	queryParams := queryValues.Encode()
-
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s%s", _baseURL, "/v1/payments/search?", queryParams), nil)
-	if err != nil {
-		return 0, err
-	}
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return 0, err
-	}
+	return g.GetTotalPaymentsWithContext(context.Background(), accessToken, status)
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+func (g *Gateway) GetTotalPaymentsWithContext(ctx context.Context, accessToken string, status string) (int, error) {
+	payments, err := g.SearchPaymentsWithContext(ctx, accessToken, PaymentSearch{
+		Status: status,
+		Limit:  1,
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	if resp.StatusCode >= http.StatusBadRequest {
-		return 0, NewError(string(body), resp.StatusCode)
-	}
-
-	var r struct {
-		Paging struct {
-			TotalPayments int `json:"total"`
-		} `json:"paging"`
-	}
-
-	if err := json.Unmarshal(body, &r); err != nil {
-		return 0, err
-	}
-
-	return r.Paging.TotalPayments, nil
+	return payments.Paging.Total, nil
 }
@@ -2,6 +2,7 @@ package mercadopago
 
 import (
     "bytes"
+    "context"
     "errors"
     "github.com/stretchr/testify/require"
     "io/ioutil"
@@ -10,11 +11,13 @@ import (
 )
 
 type ClientStub struct {
+    req  *http.Request
     resp *http.Response
     err  error
 }
 
-func (c *ClientStub) Do(_ *http.Request) (*http.Response, error) {
+func (c *ClientStub) Do(req *http.Request) (*http.Response, error) {
+    c.req = req
     if c.err != nil {
         return &http.Response{}, c.err
     }
@@ -59,7 +62,7 @@ func TestGateway_GetAccessToken_MercadoPagoError(t *testing.T) {
 
     // Then
     require.Error(t, err)
-    require.EqualError(t, err, "{\"error\": \"internal server error\"}")
+    require.EqualError(t, err, "internal server error")
 }
 
 func TestGateway_GetAccessToken_UnmarshalError(t *testing.T) {
@@ -79,7 +82,7 @@ func TestGateway_GetAccessToken_UnmarshalError(t *testing.T) {
 
     // Then
     require.Error(t, err)
-    require.EqualError(t, err, "json: cannot unmarshal number into Go struct field .access_token of type string")
+    require.EqualError(t, err, "json: cannot unmarshal number into Go struct field Token.access_token of type string")
 }
 
 func TestGateway_GetAccessToken_DoError(t *testing.T) {
@@ -105,14 +108,19 @@ func TestGateway_CreatePreference(t *testing.T) {
     c.resp = &http.Response{
         Status:     "200",
         StatusCode: 200,
-        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"init_point": "https://mercadopago.com/checkout"}`))),
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "123-abc", "client_id": "6295877106812064", "collector_id": 98765, "init_point": "https://mercadopago.com/checkout", "sandbox_init_point": "https://sandbox.mercadopago.com/checkout", "date_created": "2020-06-14T10:00:00.000-04:00"}`))),
     }
     // When
-    _, checkout, err := g.CreatePreference("", newPreference())
+    preference, err := g.CreatePreference("", newPreference())
 
     // Then
     require.NoError(t, err)
-    require.Equal(t, checkout, "https://mercadopago.com/checkout")
+    require.Equal(t, "123-abc", preference.Id)
+    require.Equal(t, "6295877106812064", preference.Client_id)
+    require.Equal(t, int64(98765), preference.Collector_id)
+    require.Equal(t, "https://mercadopago.com/checkout", preference.Init_point)
+    require.Equal(t, "https://sandbox.mercadopago.com/checkout", preference.Sandbox_init_point)
+    require.Equal(t, "2020-06-14T10:00:00.000-04:00", preference.Date_created)
 }
 
 func TestGateway_CreatePreference_MercadoPagoError(t *testing.T) {
@@ -125,11 +133,11 @@ func TestGateway_CreatePreference_MercadoPagoError(t *testing.T) {
         Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"error": "internal server error"}`))),
     }
     // When
-    _, _, err := g.CreatePreference("", newPreference())
+    _, err := g.CreatePreference("", newPreference())
 
     // Then
     require.Error(t, err)
-    require.EqualError(t, err, "{\"error\": \"internal server error\"}")
+    require.EqualError(t, err, "internal server error")
 }
 
 func TestGateway_CreatePreference_UnmarshalError(t *testing.T) {
@@ -142,11 +150,11 @@ func TestGateway_CreatePreference_UnmarshalError(t *testing.T) {
         Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"init_point": 1234}`))),
     }
     // When
-    _, _, err := g.CreatePreference("", newPreference())
+    _, err := g.CreatePreference("", newPreference())
 
     // Then
     require.Error(t, err)
-    require.EqualError(t, err, "json: cannot unmarshal number into Go struct field .init_point of type string")
+    require.EqualError(t, err, "json: cannot unmarshal number into Go struct field Preference.init_point of type string")
 }
 
 func TestGateway_CreatePreference_DoError(t *testing.T) {
@@ -155,7 +163,7 @@ func TestGateway_CreatePreference_DoError(t *testing.T) {
     g := &Gateway{Client: c}
     c.err = errors.New("do error")
     // When
-    _, _, err := g.CreatePreference("", newPreference())
+    _, err := g.CreatePreference("", newPreference())
 
     // Then
     require.Error(t, err)
@@ -196,7 +204,7 @@ func TestGateway_GetTotalPayments_MercadoPagoError(t *testing.T) {
 
     // Then
     require.Error(t, err)
-    require.EqualError(t, err, "{\"error\": \"internal server error\"}")
+    require.EqualError(t, err, "internal server error")
     require.Equal(t, 0, totalPayments)
 }
 
@@ -214,10 +222,45 @@ func TestGateway_GetTotalPayments_UnmarshalError(t *testing.T) {
 
     // Then
     require.Error(t, err)
-    require.EqualError(t, err, "json: cannot unmarshal number into Go struct field .paging of type struct { TotalPayments int \"json:\\\"total\\\"\" }")
+    require.EqualError(t, err, "json: cannot unmarshal number into Go struct field PaymentSearchResponse.paging of type mercadopago.Paging")
     require.Equal(t, 0, totalPayments)
 }
 
+func TestGateway_GetPaymentsWithContext(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "approved"}`))),
+    }
+    type key struct{}
+    ctx := context.WithValue(context.Background(), key{}, "value")
+
+    // When
+    payment, err := g.GetPaymentsWithContext(ctx, "MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 123, payment.Id)
+    require.Equal(t, "value", c.req.Context().Value(key{}))
+}
+
+func TestGateway_GetPaymentsWithContext_Cancelled(t *testing.T) {
+    // Given
+    g := &Gateway{Client: http.DefaultClient}
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    // When
+    _, err := g.GetPaymentsWithContext(ctx, "MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.Error(t, err)
+    require.True(t, errors.Is(err, context.Canceled))
+}
+
 func newPreference() NewPreference {
     return NewPreference{
         Items: []Item{
@@ -230,25 +273,25 @@ func newPreference() NewPreference {
             },
         },
         Payer: Payer{
-            Name:    "mateo",
-            Surname: "fc",
-            Email:   "m@gmail.com",
+            First_name: "mateo",
+            Last_name:  "fc",
+            Email:      "m@gmail.com",
             Phone: Phone{
-                AreaCode: "",
-                Number:   "12345",
+                Area_code: "",
+                Number:    "12345",
             },
             Address: Address{
-                ZipCode: "",
-                Street:  "pepe",
-                Number:  1234,
+                Zip_code:      "",
+                Street_name:   "pepe",
+                Street_number: 1234,
             },
             CreatedAt: "",
         },
-        Redirect: Redirect{
+        Back_urls: Back_urls{
             Success: "http://baseurl.com/success",
             Pending: "http://baseurl.com/pending",
-            failure: "http://baseurl.com/failure",
+            Failure: "http://baseurl.com/failure",
         },
-        AutoReturn: true,
+        AutoReturn: AutoReturnApproved,
     }
 }
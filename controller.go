@@ -1,19 +1,87 @@
 package mercadopago
 
+import "context"
+
+// ClientGateway is implemented by Gateway. Every operation takes a context so
+// callers can cancel MercadoPago calls or bound them with a deadline; the
+// operations of the first versions are also kept without one.
 type ClientGateway interface {
 	GetAccessToken(credentials Credentials) (string, error)
-	CreatePreference(accessToken string, preference NewPreference) (string, string, error)
+	CreatePreference(accessToken string, preference NewPreference) (Preference, error)
 	GetCheckoutPreferences(accessToken string, id string) (int, error)
 	GetPayments(accessToken string, id string) (PaymentReq, error)
 	GetPaymentsSearch(accessToken string, external_reference string) (PaymentReqSearch, error)
 	GetSubscriptionsSearch(accessToken string, external_reference string) (SubscriptionSearchResponse, error)
 	GetSubscriptionByID(accessToken string, subscriptionID string) (SubscriptionResult, error)
-	//GetMerchantOrders(accessToken string, order_id string) (MerchantOrders, error)
 	GetTotalPayments(accessToken string, status string) (int, error)
+
+	GetAccessTokenWithContext(ctx context.Context, credentials Credentials) (string, error)
+	GetTokenWithContext(ctx context.Context, credentials Credentials) (Token, error)
+	AuthorizationURL(clientID string, redirectURI string, state string, pkce *PKCE) string
+	ExchangeCodeWithContext(ctx context.Context, credentials Credentials, code string, redirectURI string, codeVerifier string) (Token, error)
+	RefreshTokenWithContext(ctx context.Context, credentials Credentials, refreshToken string) (Token, error)
+	CreatePreferenceWithContext(ctx context.Context, accessToken string, preference NewPreference) (Preference, error)
+	GetCheckoutPreferencesWithContext(ctx context.Context, accessToken string, id string) (int, error)
+	GetPreferenceWithContext(ctx context.Context, accessToken string, id string) (Preference, error)
+	UpdatePreferenceWithContext(ctx context.Context, accessToken string, id string, update PreferenceUpdate) (Preference, error)
+	SearchPreferencesWithContext(ctx context.Context, accessToken string, search PreferenceSearch) (PreferenceSearchResponse, error)
+	GetPaymentsWithContext(ctx context.Context, accessToken string, id string) (Payment, error)
+	CreatePaymentWithContext(ctx context.Context, accessToken string, payment NewPayment) (Payment, error)
+	UpdatePaymentWithContext(ctx context.Context, accessToken string, id string, update PaymentUpdate) (Payment, error)
+	CapturePaymentWithContext(ctx context.Context, accessToken string, id string) (Payment, error)
+	CancelPaymentWithContext(ctx context.Context, accessToken string, id string) (Payment, error)
+	CreatePixPaymentWithContext(ctx context.Context, accessToken string, pix NewPixPayment) (PixPayment, error)
+	CreateTicketPaymentWithContext(ctx context.Context, accessToken string, ticket NewTicketPayment) (TicketPayment, error)
+	CreateRefundWithContext(ctx context.Context, accessToken string, paymentID string, amount float64) (Refund, error)
+	CreateFullRefundWithContext(ctx context.Context, accessToken string, paymentID string) (Refund, error)
+	GetRefundWithContext(ctx context.Context, accessToken string, paymentID string, refundID string) (Refund, error)
+	GetRefundsWithContext(ctx context.Context, accessToken string, paymentID string) ([]Refund, error)
+	CreateCustomerWithContext(ctx context.Context, accessToken string, customer NewCustomer) (Customer, error)
+	GetCustomerWithContext(ctx context.Context, accessToken string, customerID string) (Customer, error)
+	UpdateCustomerWithContext(ctx context.Context, accessToken string, customerID string, update CustomerUpdate) (Customer, error)
+	DeleteCustomerWithContext(ctx context.Context, accessToken string, customerID string) (Customer, error)
+	SearchCustomersWithContext(ctx context.Context, accessToken string, search CustomerSearch) (CustomerSearchResponse, error)
+	CreateCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardToken string) (CustomerCard, error)
+	GetCustomerCardsWithContext(ctx context.Context, accessToken string, customerID string) ([]CustomerCard, error)
+	GetCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardID string) (CustomerCard, error)
+	DeleteCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardID string) (CustomerCard, error)
+	CreateCardTokenWithContext(ctx context.Context, accessToken string, card NewCardToken) (CardToken, error)
+	CreateSavedCardTokenWithContext(ctx context.Context, accessToken string, cardID string, securityCode string) (CardToken, error)
+	GetPaymentMethodsWithContext(ctx context.Context, accessToken string) ([]PaymentMethod, error)
+	GetCardIssuersWithContext(ctx context.Context, accessToken string, paymentMethodID string, bin string) ([]CardIssuer, error)
+	GetInstallmentsWithContext(ctx context.Context, accessToken string, query InstallmentsQuery) ([]Installments, error)
+	GetIdentificationTypesWithContext(ctx context.Context, accessToken string) ([]IdentificationType, error)
+	SearchPaymentsWithContext(ctx context.Context, accessToken string, search PaymentSearch) (PaymentSearchResponse, error)
+	GetPaymentsSearchWithContext(ctx context.Context, accessToken string, external_reference string) (PaymentReqSearch, error)
+	GetSubscriptionsSearchWithContext(ctx context.Context, accessToken string, external_reference string) (SubscriptionSearchResponse, error)
+	SearchSubscriptionsWithContext(ctx context.Context, accessToken string, search SubscriptionSearch) (SubscriptionSearchResponse, error)
+	GetSubscriptionByIDWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error)
+	CreateSubscriptionWithContext(ctx context.Context, accessToken string, subscription NewSubscription) (SubscriptionResult, error)
+	UpdateSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string, update SubscriptionUpdate) (SubscriptionResult, error)
+	PauseSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error)
+	ReactivateSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error)
+	CancelSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error)
+	CreatePreapprovalPlanWithContext(ctx context.Context, accessToken string, plan NewPreapprovalPlan) (PreapprovalPlan, error)
+	GetPreapprovalPlanWithContext(ctx context.Context, accessToken string, planID string) (PreapprovalPlan, error)
+	UpdatePreapprovalPlanWithContext(ctx context.Context, accessToken string, planID string, update PreapprovalPlanUpdate) (PreapprovalPlan, error)
+	SearchPreapprovalPlansWithContext(ctx context.Context, accessToken string, search PreapprovalPlanSearch) (PreapprovalPlanSearchResponse, error)
+	GetAuthorizedPaymentWithContext(ctx context.Context, accessToken string, id string) (AuthorizedPayment, error)
+	SearchAuthorizedPaymentsWithContext(ctx context.Context, accessToken string, search AuthorizedPaymentSearch) (AuthorizedPaymentSearchResponse, error)
+	GetSubscriptionChargesWithContext(ctx context.Context, accessToken string, subscriptionID string) ([]AuthorizedPayment, error)
+	GetMerchantOrderWithContext(ctx context.Context, accessToken string, id string) (MerchantOrder, error)
+	SearchMerchantOrdersWithContext(ctx context.Context, accessToken string, search MerchantOrderSearch) (MerchantOrders, error)
+	GetMerchantOrdersWithContext(ctx context.Context, accessToken string, preferenceID string) (MerchantOrders, error)
+	CreateMerchantOrderWithContext(ctx context.Context, accessToken string, order NewMerchantOrder) (MerchantOrder, error)
+	UpdateMerchantOrderWithContext(ctx context.Context, accessToken string, id string, update MerchantOrderUpdate) (MerchantOrder, error)
+	GetTotalPaymentsWithContext(ctx context.Context, accessToken string, status string) (int, error)
 }
 
+var _ ClientGateway = (*Gateway)(nil)
+
 type Controller struct {
 	Client ClientGateway
+	// TokenSource supplies the access token of calls made with an empty one.
+	TokenSource TokenSource
 }
 
 func NewController(client ClientGateway) *Controller {
@@ -22,41 +90,796 @@ func NewController(client ClientGateway) *Controller {
 	}
 }
 
+// NewControllerWithTokenSource returns a Controller whose calls take their
+// access token from tokenSource; callers pass an empty accessToken.
+func NewControllerWithTokenSource(client ClientGateway, tokenSource TokenSource) *Controller {
+	return &Controller{
+		Client:      client,
+		TokenSource: tokenSource,
+	}
+}
+
+// accessToken returns accessToken, or a token from the TokenSource when it is
+// empty.
+func (s *Controller) accessToken(ctx context.Context, accessToken string) (string, error) {
+	if accessToken != "" || s.TokenSource == nil {
+		return accessToken, nil
+	}
+
+	token, err := s.TokenSource.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
 func (s *Controller) GetAccessToken(clientID string, clientSecret string) (string, error) {
-	return s.Client.GetAccessToken(Credentials{
+	return s.GetAccessTokenWithContext(context.Background(), clientID, clientSecret)
+}
+
+func (s *Controller) GetAccessTokenWithContext(ctx context.Context, clientID string, clientSecret string) (string, error) {
+	return s.Client.GetAccessTokenWithContext(ctx, Credentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	})
+}
+
+func (s *Controller) GetToken(clientID string, clientSecret string) (Token, error) {
+	return s.GetTokenWithContext(context.Background(), clientID, clientSecret)
+}
+
+func (s *Controller) GetTokenWithContext(ctx context.Context, clientID string, clientSecret string) (Token, error) {
+	return s.Client.GetTokenWithContext(ctx, Credentials{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 	})
 }
 
-func (s *Controller) CreatePreference(accessToken string, preference NewPreference) (string, string, error) {
-	return s.Client.CreatePreference(accessToken, preference)
+func (s *Controller) AuthorizationURL(clientID string, redirectURI string, state string, pkce *PKCE) string {
+	return s.Client.AuthorizationURL(clientID, redirectURI, state, pkce)
 }
 
+func (s *Controller) ExchangeCode(clientID string, clientSecret string, code string, redirectURI string, codeVerifier string) (Token, error) {
+	return s.ExchangeCodeWithContext(context.Background(), clientID, clientSecret, code, redirectURI, codeVerifier)
+}
+
+func (s *Controller) ExchangeCodeWithContext(ctx context.Context, clientID string, clientSecret string, code string, redirectURI string, codeVerifier string) (Token, error) {
+	return s.Client.ExchangeCodeWithContext(ctx, Credentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}, code, redirectURI, codeVerifier)
+}
+
+func (s *Controller) RefreshToken(clientID string, clientSecret string, refreshToken string) (Token, error) {
+	return s.RefreshTokenWithContext(context.Background(), clientID, clientSecret, refreshToken)
+}
+
+func (s *Controller) RefreshTokenWithContext(ctx context.Context, clientID string, clientSecret string, refreshToken string) (Token, error) {
+	return s.Client.RefreshTokenWithContext(ctx, Credentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}, refreshToken)
+}
+
+func (s *Controller) CreatePreference(accessToken string, preference NewPreference) (Preference, error) {
+	return s.CreatePreferenceWithContext(context.Background(), accessToken, preference)
+}
+
+func (s *Controller) CreatePreferenceWithContext(ctx context.Context, accessToken string, preference NewPreference) (Preference, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Preference{}, err
+	}
+
+	return s.Client.CreatePreferenceWithContext(ctx, accessToken, preference)
+}
+
+// Deprecated: use GetPreference.
 func (s *Controller) GetCheckoutPreferences(accessToken string, id string) (int, error) {
-	return s.Client.GetCheckoutPreferences(accessToken, id)
+	return s.GetCheckoutPreferencesWithContext(context.Background(), accessToken, id)
+}
+
+// Deprecated: use GetPreferenceWithContext.
+func (s *Controller) GetCheckoutPreferencesWithContext(ctx context.Context, accessToken string, id string) (int, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Client.GetCheckoutPreferencesWithContext(ctx, accessToken, id)
+}
+
+func (s *Controller) GetPreference(accessToken string, id string) (Preference, error) {
+	return s.GetPreferenceWithContext(context.Background(), accessToken, id)
+}
+
+func (s *Controller) GetPreferenceWithContext(ctx context.Context, accessToken string, id string) (Preference, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Preference{}, err
+	}
+
+	return s.Client.GetPreferenceWithContext(ctx, accessToken, id)
+}
+
+func (s *Controller) UpdatePreference(accessToken string, id string, update PreferenceUpdate) (Preference, error) {
+	return s.UpdatePreferenceWithContext(context.Background(), accessToken, id, update)
+}
+
+func (s *Controller) UpdatePreferenceWithContext(ctx context.Context, accessToken string, id string, update PreferenceUpdate) (Preference, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Preference{}, err
+	}
+
+	return s.Client.UpdatePreferenceWithContext(ctx, accessToken, id, update)
+}
+
+func (s *Controller) SearchPreferences(accessToken string, search PreferenceSearch) (PreferenceSearchResponse, error) {
+	return s.SearchPreferencesWithContext(context.Background(), accessToken, search)
+}
+
+func (s *Controller) SearchPreferencesWithContext(ctx context.Context, accessToken string, search PreferenceSearch) (PreferenceSearchResponse, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PreferenceSearchResponse{}, err
+	}
+
+	return s.Client.SearchPreferencesWithContext(ctx, accessToken, search)
+}
+
+func (s *Controller) GetPayments(accessToken string, id string) (Payment, error) {
+	return s.GetPaymentsWithContext(context.Background(), accessToken, id)
+}
+
+func (s *Controller) GetPaymentsWithContext(ctx context.Context, accessToken string, id string) (Payment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	return s.Client.GetPaymentsWithContext(ctx, accessToken, id)
+}
+
+func (s *Controller) CreatePayment(accessToken string, payment NewPayment) (Payment, error) {
+	return s.CreatePaymentWithContext(context.Background(), accessToken, payment)
+}
+
+func (s *Controller) CreatePaymentWithContext(ctx context.Context, accessToken string, payment NewPayment) (Payment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	return s.Client.CreatePaymentWithContext(ctx, accessToken, payment)
+}
+
+func (s *Controller) UpdatePayment(accessToken string, id string, update PaymentUpdate) (Payment, error) {
+	return s.UpdatePaymentWithContext(context.Background(), accessToken, id, update)
+}
+
+func (s *Controller) UpdatePaymentWithContext(ctx context.Context, accessToken string, id string, update PaymentUpdate) (Payment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	return s.Client.UpdatePaymentWithContext(ctx, accessToken, id, update)
+}
+
+func (s *Controller) CapturePayment(accessToken string, id string) (Payment, error) {
+	return s.CapturePaymentWithContext(context.Background(), accessToken, id)
+}
+
+func (s *Controller) CapturePaymentWithContext(ctx context.Context, accessToken string, id string) (Payment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	return s.Client.CapturePaymentWithContext(ctx, accessToken, id)
+}
+
+func (s *Controller) CancelPayment(accessToken string, id string) (Payment, error) {
+	return s.CancelPaymentWithContext(context.Background(), accessToken, id)
+}
+
+func (s *Controller) CancelPaymentWithContext(ctx context.Context, accessToken string, id string) (Payment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	return s.Client.CancelPaymentWithContext(ctx, accessToken, id)
+}
+
+func (s *Controller) CreatePixPayment(accessToken string, pix NewPixPayment) (PixPayment, error) {
+	return s.CreatePixPaymentWithContext(context.Background(), accessToken, pix)
+}
+
+func (s *Controller) CreatePixPaymentWithContext(ctx context.Context, accessToken string, pix NewPixPayment) (PixPayment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PixPayment{}, err
+	}
+
+	return s.Client.CreatePixPaymentWithContext(ctx, accessToken, pix)
+}
+
+func (s *Controller) CreateTicketPayment(accessToken string, ticket NewTicketPayment) (TicketPayment, error) {
+	return s.CreateTicketPaymentWithContext(context.Background(), accessToken, ticket)
+}
+
+func (s *Controller) CreateTicketPaymentWithContext(ctx context.Context, accessToken string, ticket NewTicketPayment) (TicketPayment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return TicketPayment{}, err
+	}
+
+	return s.Client.CreateTicketPaymentWithContext(ctx, accessToken, ticket)
+}
+
+func (s *Controller) CreateRefund(accessToken string, paymentID string, amount float64) (Refund, error) {
+	return s.CreateRefundWithContext(context.Background(), accessToken, paymentID, amount)
+}
+
+func (s *Controller) CreateRefundWithContext(ctx context.Context, accessToken string, paymentID string, amount float64) (Refund, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	return s.Client.CreateRefundWithContext(ctx, accessToken, paymentID, amount)
+}
+
+func (s *Controller) CreateFullRefund(accessToken string, paymentID string) (Refund, error) {
+	return s.CreateFullRefundWithContext(context.Background(), accessToken, paymentID)
+}
+
+func (s *Controller) CreateFullRefundWithContext(ctx context.Context, accessToken string, paymentID string) (Refund, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	return s.Client.CreateFullRefundWithContext(ctx, accessToken, paymentID)
+}
+
+func (s *Controller) GetRefund(accessToken string, paymentID string, refundID string) (Refund, error) {
+	return s.GetRefundWithContext(context.Background(), accessToken, paymentID, refundID)
+}
+
+func (s *Controller) GetRefundWithContext(ctx context.Context, accessToken string, paymentID string, refundID string) (Refund, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Refund{}, err
+	}
+
+	return s.Client.GetRefundWithContext(ctx, accessToken, paymentID, refundID)
+}
+
+func (s *Controller) GetRefunds(accessToken string, paymentID string) ([]Refund, error) {
+	return s.GetRefundsWithContext(context.Background(), accessToken, paymentID)
+}
+
+func (s *Controller) GetRefundsWithContext(ctx context.Context, accessToken string, paymentID string) ([]Refund, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.GetRefundsWithContext(ctx, accessToken, paymentID)
+}
+
+func (s *Controller) CreateCustomer(accessToken string, customer NewCustomer) (Customer, error) {
+	return s.CreateCustomerWithContext(context.Background(), accessToken, customer)
+}
+
+func (s *Controller) CreateCustomerWithContext(ctx context.Context, accessToken string, customer NewCustomer) (Customer, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Customer{}, err
+	}
+
+	return s.Client.CreateCustomerWithContext(ctx, accessToken, customer)
+}
+
+func (s *Controller) GetCustomer(accessToken string, customerID string) (Customer, error) {
+	return s.GetCustomerWithContext(context.Background(), accessToken, customerID)
+}
+
+func (s *Controller) GetCustomerWithContext(ctx context.Context, accessToken string, customerID string) (Customer, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Customer{}, err
+	}
+
+	return s.Client.GetCustomerWithContext(ctx, accessToken, customerID)
+}
+
+func (s *Controller) UpdateCustomer(accessToken string, customerID string, update CustomerUpdate) (Customer, error) {
+	return s.UpdateCustomerWithContext(context.Background(), accessToken, customerID, update)
+}
+
+func (s *Controller) UpdateCustomerWithContext(ctx context.Context, accessToken string, customerID string, update CustomerUpdate) (Customer, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Customer{}, err
+	}
+
+	return s.Client.UpdateCustomerWithContext(ctx, accessToken, customerID, update)
+}
+
+func (s *Controller) DeleteCustomer(accessToken string, customerID string) (Customer, error) {
+	return s.DeleteCustomerWithContext(context.Background(), accessToken, customerID)
+}
+
+func (s *Controller) DeleteCustomerWithContext(ctx context.Context, accessToken string, customerID string) (Customer, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return Customer{}, err
+	}
+
+	return s.Client.DeleteCustomerWithContext(ctx, accessToken, customerID)
+}
+
+func (s *Controller) SearchCustomers(accessToken string, search CustomerSearch) (CustomerSearchResponse, error) {
+	return s.SearchCustomersWithContext(context.Background(), accessToken, search)
+}
+
+func (s *Controller) SearchCustomersWithContext(ctx context.Context, accessToken string, search CustomerSearch) (CustomerSearchResponse, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return CustomerSearchResponse{}, err
+	}
+
+	return s.Client.SearchCustomersWithContext(ctx, accessToken, search)
+}
+
+func (s *Controller) CreateCustomerCard(accessToken string, customerID string, cardToken string) (CustomerCard, error) {
+	return s.CreateCustomerCardWithContext(context.Background(), accessToken, customerID, cardToken)
+}
+
+func (s *Controller) CreateCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardToken string) (CustomerCard, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return CustomerCard{}, err
+	}
+
+	return s.Client.CreateCustomerCardWithContext(ctx, accessToken, customerID, cardToken)
+}
+
+func (s *Controller) GetCustomerCards(accessToken string, customerID string) ([]CustomerCard, error) {
+	return s.GetCustomerCardsWithContext(context.Background(), accessToken, customerID)
+}
+
+func (s *Controller) GetCustomerCardsWithContext(ctx context.Context, accessToken string, customerID string) ([]CustomerCard, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.GetCustomerCardsWithContext(ctx, accessToken, customerID)
+}
+
+func (s *Controller) GetCustomerCard(accessToken string, customerID string, cardID string) (CustomerCard, error) {
+	return s.GetCustomerCardWithContext(context.Background(), accessToken, customerID, cardID)
+}
+
+func (s *Controller) GetCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardID string) (CustomerCard, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return CustomerCard{}, err
+	}
+
+	return s.Client.GetCustomerCardWithContext(ctx, accessToken, customerID, cardID)
 }
 
-func (s *Controller) GetPayments(accessToken string, id string) (PaymentReq, error) {
-	return s.Client.GetPayments(accessToken, id)
+func (s *Controller) DeleteCustomerCard(accessToken string, customerID string, cardID string) (CustomerCard, error) {
+	return s.DeleteCustomerCardWithContext(context.Background(), accessToken, customerID, cardID)
 }
 
+func (s *Controller) DeleteCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardID string) (CustomerCard, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return CustomerCard{}, err
+	}
+
+	return s.Client.DeleteCustomerCardWithContext(ctx, accessToken, customerID, cardID)
+}
+
+func (s *Controller) CreateCardToken(accessToken string, card NewCardToken) (CardToken, error) {
+	return s.CreateCardTokenWithContext(context.Background(), accessToken, card)
+}
+
+func (s *Controller) CreateCardTokenWithContext(ctx context.Context, accessToken string, card NewCardToken) (CardToken, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return CardToken{}, err
+	}
+
+	return s.Client.CreateCardTokenWithContext(ctx, accessToken, card)
+}
+
+func (s *Controller) CreateSavedCardToken(accessToken string, cardID string, securityCode string) (CardToken, error) {
+	return s.CreateSavedCardTokenWithContext(context.Background(), accessToken, cardID, securityCode)
+}
+
+func (s *Controller) CreateSavedCardTokenWithContext(ctx context.Context, accessToken string, cardID string, securityCode string) (CardToken, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return CardToken{}, err
+	}
+
+	return s.Client.CreateSavedCardTokenWithContext(ctx, accessToken, cardID, securityCode)
+}
+
+func (s *Controller) GetPaymentMethods(accessToken string) ([]PaymentMethod, error) {
+	return s.GetPaymentMethodsWithContext(context.Background(), accessToken)
+}
+
+func (s *Controller) GetPaymentMethodsWithContext(ctx context.Context, accessToken string) ([]PaymentMethod, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.GetPaymentMethodsWithContext(ctx, accessToken)
+}
+
+func (s *Controller) GetCardIssuers(accessToken string, paymentMethodID string, bin string) ([]CardIssuer, error) {
+	return s.GetCardIssuersWithContext(context.Background(), accessToken, paymentMethodID, bin)
+}
+
+func (s *Controller) GetCardIssuersWithContext(ctx context.Context, accessToken string, paymentMethodID string, bin string) ([]CardIssuer, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.GetCardIssuersWithContext(ctx, accessToken, paymentMethodID, bin)
+}
+
+func (s *Controller) GetInstallments(accessToken string, query InstallmentsQuery) ([]Installments, error) {
+	return s.GetInstallmentsWithContext(context.Background(), accessToken, query)
+}
+
+func (s *Controller) GetInstallmentsWithContext(ctx context.Context, accessToken string, query InstallmentsQuery) ([]Installments, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.GetInstallmentsWithContext(ctx, accessToken, query)
+}
+
+func (s *Controller) GetIdentificationTypes(accessToken string) ([]IdentificationType, error) {
+	return s.GetIdentificationTypesWithContext(context.Background(), accessToken)
+}
+
+func (s *Controller) GetIdentificationTypesWithContext(ctx context.Context, accessToken string) ([]IdentificationType, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.GetIdentificationTypesWithContext(ctx, accessToken)
+}
+
+func (s *Controller) SearchPayments(accessToken string, search PaymentSearch) (PaymentSearchResponse, error) {
+	return s.SearchPaymentsWithContext(context.Background(), accessToken, search)
+}
+
+func (s *Controller) SearchPaymentsWithContext(ctx context.Context, accessToken string, search PaymentSearch) (PaymentSearchResponse, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PaymentSearchResponse{}, err
+	}
+
+	return s.Client.SearchPaymentsWithContext(ctx, accessToken, search)
+}
+
+// PaymentIterator returns an iterator over every payment matching search,
+// starting at search.Offset.
+func (s *Controller) PaymentIterator(accessToken string, search PaymentSearch) *PaymentIterator {
+	return newPaymentIterator(search, func(ctx context.Context, search PaymentSearch) (PaymentSearchResponse, error) {
+		return s.SearchPaymentsWithContext(ctx, accessToken, search)
+	})
+}
+
+// Deprecated: use SearchPayments.
 func (s *Controller) GetPaymentsSearch(accessToken string, external_reference string) (PaymentReqSearch, error) {
-	return s.Client.GetPaymentsSearch(accessToken, external_reference)
+	return s.GetPaymentsSearchWithContext(context.Background(), accessToken, external_reference)
+}
+
+// Deprecated: use SearchPaymentsWithContext.
+func (s *Controller) GetPaymentsSearchWithContext(ctx context.Context, accessToken string, external_reference string) (PaymentReqSearch, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PaymentReqSearch{}, err
+	}
+
+	return s.Client.GetPaymentsSearchWithContext(ctx, accessToken, external_reference)
 }
 
 func (s *Controller) GetSubscriptionsSearch(accessToken string, external_reference string) (SubscriptionSearchResponse, error) {
-	return s.Client.GetSubscriptionsSearch(accessToken, external_reference)
+	return s.GetSubscriptionsSearchWithContext(context.Background(), accessToken, external_reference)
+}
+
+func (s *Controller) GetSubscriptionsSearchWithContext(ctx context.Context, accessToken string, external_reference string) (SubscriptionSearchResponse, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionSearchResponse{}, err
+	}
+
+	return s.Client.GetSubscriptionsSearchWithContext(ctx, accessToken, external_reference)
+}
+
+func (s *Controller) SearchSubscriptions(accessToken string, search SubscriptionSearch) (SubscriptionSearchResponse, error) {
+	return s.SearchSubscriptionsWithContext(context.Background(), accessToken, search)
+}
+
+func (s *Controller) SearchSubscriptionsWithContext(ctx context.Context, accessToken string, search SubscriptionSearch) (SubscriptionSearchResponse, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionSearchResponse{}, err
+	}
+
+	return s.Client.SearchSubscriptionsWithContext(ctx, accessToken, search)
+}
+
+// SubscriptionIterator returns an iterator over every subscription matching
+// search, starting at search.Offset.
+func (s *Controller) SubscriptionIterator(accessToken string, search SubscriptionSearch) *SubscriptionIterator {
+	return newSubscriptionIterator(search, func(ctx context.Context, search SubscriptionSearch) (SubscriptionSearchResponse, error) {
+		return s.SearchSubscriptionsWithContext(ctx, accessToken, search)
+	})
 }
 
 func (s *Controller) GetSubscriptionByID(accessToken string, subscriptionID string) (SubscriptionResult, error) {
-	return s.Client.GetSubscriptionByID(accessToken, subscriptionID)
+	return s.GetSubscriptionByIDWithContext(context.Background(), accessToken, subscriptionID)
 }
 
-/*func (s *Controller) GetMerchantOrders(accessToken string, order_id string) (MerchantOrders, error) {
-    return s.Client.GetMerchantOrders(accessToken, order_id)
-}*/
+func (s *Controller) GetSubscriptionByIDWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionResult{}, err
+	}
+
+	return s.Client.GetSubscriptionByIDWithContext(ctx, accessToken, subscriptionID)
+}
+
+func (s *Controller) CreateSubscription(accessToken string, subscription NewSubscription) (SubscriptionResult, error) {
+	return s.CreateSubscriptionWithContext(context.Background(), accessToken, subscription)
+}
+
+func (s *Controller) CreateSubscriptionWithContext(ctx context.Context, accessToken string, subscription NewSubscription) (SubscriptionResult, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionResult{}, err
+	}
+
+	return s.Client.CreateSubscriptionWithContext(ctx, accessToken, subscription)
+}
+
+func (s *Controller) UpdateSubscription(accessToken string, subscriptionID string, update SubscriptionUpdate) (SubscriptionResult, error) {
+	return s.UpdateSubscriptionWithContext(context.Background(), accessToken, subscriptionID, update)
+}
+
+func (s *Controller) UpdateSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string, update SubscriptionUpdate) (SubscriptionResult, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionResult{}, err
+	}
+
+	return s.Client.UpdateSubscriptionWithContext(ctx, accessToken, subscriptionID, update)
+}
+
+func (s *Controller) PauseSubscription(accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return s.PauseSubscriptionWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (s *Controller) PauseSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionResult{}, err
+	}
+
+	return s.Client.PauseSubscriptionWithContext(ctx, accessToken, subscriptionID)
+}
+
+func (s *Controller) ReactivateSubscription(accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return s.ReactivateSubscriptionWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (s *Controller) ReactivateSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionResult{}, err
+	}
+
+	return s.Client.ReactivateSubscriptionWithContext(ctx, accessToken, subscriptionID)
+}
+
+func (s *Controller) CancelSubscription(accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return s.CancelSubscriptionWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (s *Controller) CancelSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return SubscriptionResult{}, err
+	}
+
+	return s.Client.CancelSubscriptionWithContext(ctx, accessToken, subscriptionID)
+}
+
+func (s *Controller) CreatePreapprovalPlan(accessToken string, plan NewPreapprovalPlan) (PreapprovalPlan, error) {
+	return s.CreatePreapprovalPlanWithContext(context.Background(), accessToken, plan)
+}
+
+func (s *Controller) CreatePreapprovalPlanWithContext(ctx context.Context, accessToken string, plan NewPreapprovalPlan) (PreapprovalPlan, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PreapprovalPlan{}, err
+	}
+
+	return s.Client.CreatePreapprovalPlanWithContext(ctx, accessToken, plan)
+}
+
+func (s *Controller) GetPreapprovalPlan(accessToken string, planID string) (PreapprovalPlan, error) {
+	return s.GetPreapprovalPlanWithContext(context.Background(), accessToken, planID)
+}
+
+func (s *Controller) GetPreapprovalPlanWithContext(ctx context.Context, accessToken string, planID string) (PreapprovalPlan, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PreapprovalPlan{}, err
+	}
+
+	return s.Client.GetPreapprovalPlanWithContext(ctx, accessToken, planID)
+}
+
+func (s *Controller) UpdatePreapprovalPlan(accessToken string, planID string, update PreapprovalPlanUpdate) (PreapprovalPlan, error) {
+	return s.UpdatePreapprovalPlanWithContext(context.Background(), accessToken, planID, update)
+}
+
+func (s *Controller) UpdatePreapprovalPlanWithContext(ctx context.Context, accessToken string, planID string, update PreapprovalPlanUpdate) (PreapprovalPlan, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PreapprovalPlan{}, err
+	}
+
+	return s.Client.UpdatePreapprovalPlanWithContext(ctx, accessToken, planID, update)
+}
+
+func (s *Controller) SearchPreapprovalPlans(accessToken string, search PreapprovalPlanSearch) (PreapprovalPlanSearchResponse, error) {
+	return s.SearchPreapprovalPlansWithContext(context.Background(), accessToken, search)
+}
+
+func (s *Controller) SearchPreapprovalPlansWithContext(ctx context.Context, accessToken string, search PreapprovalPlanSearch) (PreapprovalPlanSearchResponse, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return PreapprovalPlanSearchResponse{}, err
+	}
+
+	return s.Client.SearchPreapprovalPlansWithContext(ctx, accessToken, search)
+}
+
+func (s *Controller) GetAuthorizedPayment(accessToken string, id string) (AuthorizedPayment, error) {
+	return s.GetAuthorizedPaymentWithContext(context.Background(), accessToken, id)
+}
+
+func (s *Controller) GetAuthorizedPaymentWithContext(ctx context.Context, accessToken string, id string) (AuthorizedPayment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return AuthorizedPayment{}, err
+	}
+
+	return s.Client.GetAuthorizedPaymentWithContext(ctx, accessToken, id)
+}
+
+func (s *Controller) SearchAuthorizedPayments(accessToken string, search AuthorizedPaymentSearch) (AuthorizedPaymentSearchResponse, error) {
+	return s.SearchAuthorizedPaymentsWithContext(context.Background(), accessToken, search)
+}
+
+func (s *Controller) SearchAuthorizedPaymentsWithContext(ctx context.Context, accessToken string, search AuthorizedPaymentSearch) (AuthorizedPaymentSearchResponse, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return AuthorizedPaymentSearchResponse{}, err
+	}
+
+	return s.Client.SearchAuthorizedPaymentsWithContext(ctx, accessToken, search)
+}
+
+func (s *Controller) GetSubscriptionCharges(accessToken string, subscriptionID string) ([]AuthorizedPayment, error) {
+	return s.GetSubscriptionChargesWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (s *Controller) GetSubscriptionChargesWithContext(ctx context.Context, accessToken string, subscriptionID string) ([]AuthorizedPayment, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Client.GetSubscriptionChargesWithContext(ctx, accessToken, subscriptionID)
+}
+
+func (s *Controller) GetMerchantOrder(accessToken string, id string) (MerchantOrder, error) {
+	return s.GetMerchantOrderWithContext(context.Background(), accessToken, id)
+}
+
+func (s *Controller) GetMerchantOrderWithContext(ctx context.Context, accessToken string, id string) (MerchantOrder, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return MerchantOrder{}, err
+	}
+
+	return s.Client.GetMerchantOrderWithContext(ctx, accessToken, id)
+}
+
+func (s *Controller) SearchMerchantOrders(accessToken string, search MerchantOrderSearch) (MerchantOrders, error) {
+	return s.SearchMerchantOrdersWithContext(context.Background(), accessToken, search)
+}
+
+func (s *Controller) SearchMerchantOrdersWithContext(ctx context.Context, accessToken string, search MerchantOrderSearch) (MerchantOrders, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return MerchantOrders{}, err
+	}
+
+	return s.Client.SearchMerchantOrdersWithContext(ctx, accessToken, search)
+}
+
+func (s *Controller) GetMerchantOrders(accessToken string, preferenceID string) (MerchantOrders, error) {
+	return s.GetMerchantOrdersWithContext(context.Background(), accessToken, preferenceID)
+}
+
+func (s *Controller) GetMerchantOrdersWithContext(ctx context.Context, accessToken string, preferenceID string) (MerchantOrders, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return MerchantOrders{}, err
+	}
+
+	return s.Client.GetMerchantOrdersWithContext(ctx, accessToken, preferenceID)
+}
+
+func (s *Controller) CreateMerchantOrder(accessToken string, order NewMerchantOrder) (MerchantOrder, error) {
+	return s.CreateMerchantOrderWithContext(context.Background(), accessToken, order)
+}
+
+func (s *Controller) CreateMerchantOrderWithContext(ctx context.Context, accessToken string, order NewMerchantOrder) (MerchantOrder, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return MerchantOrder{}, err
+	}
+
+	return s.Client.CreateMerchantOrderWithContext(ctx, accessToken, order)
+}
+
+func (s *Controller) UpdateMerchantOrder(accessToken string, id string, update MerchantOrderUpdate) (MerchantOrder, error) {
+	return s.UpdateMerchantOrderWithContext(context.Background(), accessToken, id, update)
+}
+
+func (s *Controller) UpdateMerchantOrderWithContext(ctx context.Context, accessToken string, id string, update MerchantOrderUpdate) (MerchantOrder, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return MerchantOrder{}, err
+	}
+
+	return s.Client.UpdateMerchantOrderWithContext(ctx, accessToken, id, update)
+}
 
 func (s *Controller) GetTotalPayments(accessToken string, status string) (int, error) {
-	return s.Client.GetTotalPayments(accessToken, status)
+	return s.GetTotalPaymentsWithContext(context.Background(), accessToken, status)
+}
+
+func (s *Controller) GetTotalPaymentsWithContext(ctx context.Context, accessToken string, status string) (int, error) {
+	accessToken, err := s.accessToken(ctx, accessToken)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Client.GetTotalPaymentsWithContext(ctx, accessToken, status)
 }
@@ -0,0 +1,237 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PaymentPayerTypeCustomer is the payer type of payments made by a customer,
+// identified by PaymentPayer.Id.
+const PaymentPayerTypeCustomer = "customer"
+
+// Customer is the customer resource returned by /v1/customers. It keeps the
+// cards and addresses of a returning payer.
+type Customer struct {
+	Id                string                 `json:"id"`
+	Email             string                 `json:"email"`
+	First_name        string                 `json:"first_name"`
+	Last_name         string                 `json:"last_name"`
+	Phone             Phone                  `json:"phone"`
+	Identification    Identification         `json:"identification"`
+	Address           CustomerAddress        `json:"address"`
+	Default_address   string                 `json:"default_address"`
+	Default_card      string                 `json:"default_card"`
+	Description       string                 `json:"description"`
+	Cards             []CustomerCard         `json:"cards"`
+	Addresses         []CustomerAddress      `json:"addresses"`
+	Metadata          map[string]interface{} `json:"metadata"`
+	Live_mode         bool                   `json:"live_mode"`
+	Date_registered   string                 `json:"date_registered"`
+	Date_created      string                 `json:"date_created"`
+	Date_last_updated string                 `json:"date_last_updated"`
+}
+
+type CustomerAddress struct {
+	Id            string `json:"id,omitempty"`
+	Zip_code      string `json:"zip_code,omitempty"`
+	Street_name   string `json:"street_name,omitempty"`
+	Street_number int    `json:"street_number,omitempty"`
+	City          string `json:"city,omitempty"`
+}
+
+// CustomerCard is a card saved for a customer. Payments with it send a token
+// created from its Id and security code.
+type CustomerCard struct {
+	Id                string                    `json:"id"`
+	Customer_id       string                    `json:"customer_id"`
+	User_id           string                    `json:"user_id"`
+	First_six_digits  string                    `json:"first_six_digits"`
+	Last_four_digits  string                    `json:"last_four_digits"`
+	Expiration_month  int                       `json:"expiration_month"`
+	Expiration_year   int                       `json:"expiration_year"`
+	Payment_method    CustomerCardPaymentMethod `json:"payment_method"`
+	Issuer            CustomerCardIssuer        `json:"issuer"`
+	Security_code     CustomerCardSecurityCode  `json:"security_code"`
+	Cardholder        Cardholder                `json:"cardholder"`
+	Live_mode         bool                      `json:"live_mode"`
+	Date_created      string                    `json:"date_created"`
+	Date_last_updated string                    `json:"date_last_updated"`
+}
+
+type CustomerCardPaymentMethod struct {
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	Payment_type_id  string `json:"payment_type_id"`
+	Thumbnail        string `json:"thumbnail"`
+	Secure_thumbnail string `json:"secure_thumbnail"`
+}
+
+type CustomerCardIssuer struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type CustomerCardSecurityCode struct {
+	Length        int    `json:"length"`
+	Card_location string `json:"card_location"`
+}
+
+type Cardholder struct {
+	Name           string         `json:"name"`
+	Identification Identification `json:"identification"`
+}
+
+// NewCustomer is the body of CreateCustomer.
+type NewCustomer struct {
+	Email           string                 `json:"email" validate:"required"`
+	First_name      string                 `json:"first_name,omitempty"`
+	Last_name       string                 `json:"last_name,omitempty"`
+	Phone           *Phone                 `json:"phone,omitempty"`
+	Identification  *Identification        `json:"identification,omitempty"`
+	Address         *CustomerAddress       `json:"address,omitempty"`
+	Default_address string                 `json:"default_address,omitempty"`
+	Default_card    string                 `json:"default_card,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	Date_registered string                 `json:"date_registered,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// CustomerUpdate is the body of UpdateCustomer; only the fields set are
+// changed.
+type CustomerUpdate struct {
+	Email           string                 `json:"email,omitempty"`
+	First_name      string                 `json:"first_name,omitempty"`
+	Last_name       string                 `json:"last_name,omitempty"`
+	Phone           *Phone                 `json:"phone,omitempty"`
+	Identification  *Identification        `json:"identification,omitempty"`
+	Address         *CustomerAddress       `json:"address,omitempty"`
+	Default_address string                 `json:"default_address,omitempty"`
+	Default_card    string                 `json:"default_card,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// CustomerSearch filters a customers search. Empty fields are left out of
+// the query.
+type CustomerSearch struct {
+	Email  string
+	Limit  int
+	Offset int
+}
+
+func (s CustomerSearch) query() string {
+	q := url.Values{}
+	if s.Email != "" {
+		q.Set("email", s.Email)
+	}
+	if s.Limit > 0 {
+		q.Set("limit", strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		q.Set("offset", strconv.Itoa(s.Offset))
+	}
+	return q.Encode()
+}
+
+type CustomerSearchResponse struct {
+	Paging  Paging     `json:"paging"`
+	Results []Customer `json:"results"`
+}
+
+// CustomerPayer returns the payer of a payment made by the customer
+// customerID, typically with one of its saved cards.
+func CustomerPayer(customerID string) PaymentPayer {
+	return PaymentPayer{
+		Id:   customerID,
+		Type: PaymentPayerTypeCustomer,
+	}
+}
+
+func (g *Gateway) CreateCustomer(accessToken string, customer NewCustomer) (Customer, error) {
+	return g.CreateCustomerWithContext(context.Background(), accessToken, customer)
+}
+
+func (g *Gateway) CreateCustomerWithContext(ctx context.Context, accessToken string, customer NewCustomer) (created Customer, err error) {
+	err = g.doJSON(ctx, http.MethodPost, "/v1/customers", accessToken, customer, &created)
+	return
+}
+
+func (g *Gateway) GetCustomer(accessToken string, customerID string) (Customer, error) {
+	return g.GetCustomerWithContext(context.Background(), accessToken, customerID)
+}
+
+func (g *Gateway) GetCustomerWithContext(ctx context.Context, accessToken string, customerID string) (customer Customer, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/customers/"+customerID, accessToken, nil, &customer)
+	return
+}
+
+func (g *Gateway) UpdateCustomer(accessToken string, customerID string, update CustomerUpdate) (Customer, error) {
+	return g.UpdateCustomerWithContext(context.Background(), accessToken, customerID, update)
+}
+
+func (g *Gateway) UpdateCustomerWithContext(ctx context.Context, accessToken string, customerID string, update CustomerUpdate) (updated Customer, err error) {
+	err = g.doJSON(ctx, http.MethodPut, "/v1/customers/"+customerID, accessToken, update, &updated)
+	return
+}
+
+func (g *Gateway) DeleteCustomer(accessToken string, customerID string) (Customer, error) {
+	return g.DeleteCustomerWithContext(context.Background(), accessToken, customerID)
+}
+
+func (g *Gateway) DeleteCustomerWithContext(ctx context.Context, accessToken string, customerID string) (deleted Customer, err error) {
+	err = g.doJSON(ctx, http.MethodDelete, "/v1/customers/"+customerID, accessToken, nil, &deleted)
+	return
+}
+
+func (g *Gateway) SearchCustomers(accessToken string, search CustomerSearch) (CustomerSearchResponse, error) {
+	return g.SearchCustomersWithContext(context.Background(), accessToken, search)
+}
+
+func (g *Gateway) SearchCustomersWithContext(ctx context.Context, accessToken string, search CustomerSearch) (customers CustomerSearchResponse, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/customers/search?"+search.query(), accessToken, nil, &customers)
+	return
+}
+
+// CreateCustomerCard saves the card of cardToken, a token created with the
+// card data, for the customer.
+func (g *Gateway) CreateCustomerCard(accessToken string, customerID string, cardToken string) (CustomerCard, error) {
+	return g.CreateCustomerCardWithContext(context.Background(), accessToken, customerID, cardToken)
+}
+
+func (g *Gateway) CreateCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardToken string) (card CustomerCard, err error) {
+	body := struct {
+		Token string `json:"token"`
+	}{cardToken}
+
+	err = g.doJSON(ctx, http.MethodPost, "/v1/customers/"+customerID+"/cards", accessToken, body, &card)
+	return
+}
+
+func (g *Gateway) GetCustomerCards(accessToken string, customerID string) ([]CustomerCard, error) {
+	return g.GetCustomerCardsWithContext(context.Background(), accessToken, customerID)
+}
+
+func (g *Gateway) GetCustomerCardsWithContext(ctx context.Context, accessToken string, customerID string) (cards []CustomerCard, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/customers/"+customerID+"/cards", accessToken, nil, &cards)
+	return
+}
+
+func (g *Gateway) GetCustomerCard(accessToken string, customerID string, cardID string) (CustomerCard, error) {
+	return g.GetCustomerCardWithContext(context.Background(), accessToken, customerID, cardID)
+}
+
+func (g *Gateway) GetCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardID string) (card CustomerCard, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/customers/"+customerID+"/cards/"+cardID, accessToken, nil, &card)
+	return
+}
+
+func (g *Gateway) DeleteCustomerCard(accessToken string, customerID string, cardID string) (CustomerCard, error) {
+	return g.DeleteCustomerCardWithContext(context.Background(), accessToken, customerID, cardID)
+}
+
+func (g *Gateway) DeleteCustomerCardWithContext(ctx context.Context, accessToken string, customerID string, cardID string) (card CustomerCard, err error) {
+	err = g.doJSON(ctx, http.MethodDelete, "/v1/customers/"+customerID+"/cards/"+cardID, accessToken, nil, &card)
+	return
+}
@@ -0,0 +1,228 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreateCustomer(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "123456789-jxOV430go9fx2e", "email": "test_user@testuser.com", "first_name": "Test", "identification": {"type": "CPF", "number": "19119119100"}, "cards": []}`))),
+    }
+    // When
+    customer, err := g.CreateCustomer("MY_ACCESS_TOKEN", NewCustomer{
+        Email:          "test_user@testuser.com",
+        First_name:     "Test",
+        Identification: &Identification{Type: "CPF", Number: "19119119100"},
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "123456789-jxOV430go9fx2e", customer.Id)
+    require.Equal(t, "19119119100", customer.Identification.Number)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/v1/customers", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"email": "test_user@testuser.com", "first_name": "Test", "identification": {"type": "CPF", "number": "19119119100"}}`, string(b))
+}
+
+func TestGateway_GetCustomer(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "CUSTOMER_ID", "email": "test_user@testuser.com", "default_card": "CARD_ID", "cards": [{"id": "CARD_ID", "customer_id": "CUSTOMER_ID", "last_four_digits": "3704", "payment_method": {"id": "visa", "payment_type_id": "credit_card"}, "security_code": {"length": 3, "card_location": "back"}, "issuer": {"id": 25, "name": "Visa"}}]}`))),
+    }
+    // When
+    customer, err := g.GetCustomer("MY_ACCESS_TOKEN", "CUSTOMER_ID")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "CARD_ID", customer.Default_card)
+    require.Len(t, customer.Cards, 1)
+    require.Equal(t, "visa", customer.Cards[0].Payment_method.Id)
+    require.Equal(t, 3, customer.Cards[0].Security_code.Length)
+    require.Equal(t, int64(25), customer.Cards[0].Issuer.Id)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/v1/customers/CUSTOMER_ID", c.req.URL.Path)
+}
+
+func TestGateway_UpdateCustomer(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "CUSTOMER_ID", "default_card": "OTHER_CARD_ID"}`))),
+    }
+    // When
+    customer, err := g.UpdateCustomer("MY_ACCESS_TOKEN", "CUSTOMER_ID", CustomerUpdate{Default_card: "OTHER_CARD_ID"})
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "OTHER_CARD_ID", customer.Default_card)
+    require.Equal(t, http.MethodPut, c.req.Method)
+    require.Equal(t, "/v1/customers/CUSTOMER_ID", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"default_card": "OTHER_CARD_ID"}`, string(b))
+}
+
+func TestGateway_DeleteCustomer(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "CUSTOMER_ID"}`))),
+    }
+    // When
+    customer, err := g.DeleteCustomer("MY_ACCESS_TOKEN", "CUSTOMER_ID")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "CUSTOMER_ID", customer.Id)
+    require.Equal(t, http.MethodDelete, c.req.Method)
+    require.Equal(t, "/v1/customers/CUSTOMER_ID", c.req.URL.Path)
+}
+
+func TestGateway_SearchCustomers(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"limit": 10, "offset": 0, "total": 1}, "results": [{"id": "CUSTOMER_ID", "email": "test_user+1@testuser.com"}]}`))),
+    }
+    // When
+    customers, err := g.SearchCustomers("MY_ACCESS_TOKEN", CustomerSearch{Email: "test_user+1@testuser.com", Limit: 10})
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 1, customers.Paging.Total)
+    require.Equal(t, "CUSTOMER_ID", customers.Results[0].Id)
+    require.Equal(t, "/v1/customers/search", c.req.URL.Path)
+    require.Equal(t, "email=test_user%2B1%40testuser.com&limit=10", c.req.URL.RawQuery)
+}
+
+func TestGateway_CreateCustomerCard(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "CARD_ID", "customer_id": "CUSTOMER_ID", "first_six_digits": "423564", "last_four_digits": "5682", "expiration_month": 11, "expiration_year": 2030, "cardholder": {"name": "APRO", "identification": {"type": "CPF", "number": "19119119100"}}}`))),
+    }
+    // When
+    card, err := g.CreateCustomerCard("MY_ACCESS_TOKEN", "CUSTOMER_ID", "CARD_TOKEN")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "CARD_ID", card.Id)
+    require.Equal(t, "APRO", card.Cardholder.Name)
+    require.Equal(t, 2030, card.Expiration_year)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/v1/customers/CUSTOMER_ID/cards", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"token": "CARD_TOKEN"}`, string(b))
+}
+
+func TestGateway_GetCustomerCards(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id": "CARD_1"}, {"id": "CARD_2"}]`))),
+    }
+    // When
+    cards, err := g.GetCustomerCards("MY_ACCESS_TOKEN", "CUSTOMER_ID")
+
+    // Then
+    require.NoError(t, err)
+    require.Len(t, cards, 2)
+    require.Equal(t, "/v1/customers/CUSTOMER_ID/cards", c.req.URL.Path)
+}
+
+func TestGateway_GetCustomerCard(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "CARD_ID"}`))),
+    }
+    // When
+    card, err := g.GetCustomerCard("MY_ACCESS_TOKEN", "CUSTOMER_ID", "CARD_ID")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "CARD_ID", card.Id)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/v1/customers/CUSTOMER_ID/cards/CARD_ID", c.req.URL.Path)
+}
+
+func TestGateway_DeleteCustomerCard(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "404",
+        StatusCode: 404,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message": "card not found", "error": "not_found", "status": 404}`))),
+    }
+    // When
+    _, err := g.DeleteCustomerCard("MY_ACCESS_TOKEN", "CUSTOMER_ID", "CARD_ID")
+
+    // Then
+    require.True(t, IsNotFound(err))
+    require.Equal(t, http.MethodDelete, c.req.Method)
+    require.Equal(t, "/v1/customers/CUSTOMER_ID/cards/CARD_ID", c.req.URL.Path)
+}
+
+func TestGateway_CreatePayment_WithCustomerCard(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "approved"}`))),
+    }
+    // When
+    _, err := g.CreatePayment("MY_ACCESS_TOKEN", NewPayment{
+        Transaction_amount: 100,
+        Token:              "SAVED_CARD_TOKEN",
+        Installments:       1,
+        Payment_method_id:  "visa",
+        Payer:              CustomerPayer("CUSTOMER_ID"),
+    })
+
+    // Then
+    require.NoError(t, err)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"transaction_amount": 100, "token": "SAVED_CARD_TOKEN", "installments": 1, "payment_method_id": "visa", "payer": {"id": "CUSTOMER_ID", "type": "customer"}}`, string(b))
+}
@@ -1,10 +1,29 @@
 package mercadopago
 
-import "fmt"
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+)
 
 type Error struct {
     Message string
     StatusCode int
+    // Code is the error identifier sent by MercadoPago in the "error" field,
+    // e.g. "bad_request" or "not_found".
+    Code string
+    Causes []Cause
+    // Body is the raw response body the error was parsed from.
+    Body string
+}
+
+// Cause is one entry of the "cause" list MercadoPago attaches to an error,
+// such as {"code": "2006", "description": "Card Token not found"}.
+type Cause struct {
+    Code string
+    Description string
 }
 
 func NewError(message string, statusCode int) *Error {
@@ -14,6 +33,124 @@ func NewError(message string, statusCode int) *Error {
     }
 }
 
+// newAPIError builds an Error from a MercadoPago error response. Bodies that
+// aren't an error envelope are kept whole as the message.
+func newAPIError(body []byte, statusCode int) *Error {
+    e := &Error{
+        Message:    string(body),
+        StatusCode: statusCode,
+        Body:       string(body),
+    }
+
+    var envelope struct {
+        Message string          `json:"message"`
+        Error   string          `json:"error"`
+        Cause   json.RawMessage `json:"cause"`
+    }
+    if err := json.Unmarshal(body, &envelope); err != nil {
+        return e
+    }
+
+    e.Code = envelope.Error
+    e.Causes = parseCauses(envelope.Cause)
+
+    if envelope.Message != "" {
+        e.Message = envelope.Message
+    } else if envelope.Error != "" {
+        e.Message = envelope.Error
+    }
+
+    return e
+}
+
+// parseCauses accepts "cause" both as a list and as a single object, with
+// codes sent either as numbers or as strings.
+func parseCauses(raw json.RawMessage) []Cause {
+    raw = bytes.TrimSpace(raw)
+    if len(raw) == 0 || string(raw) == "null" {
+        return nil
+    }
+
+    type cause struct {
+        Code        json.RawMessage `json:"code"`
+        Description string          `json:"description"`
+    }
+
+    var list []cause
+    if raw[0] == '{' {
+        var c cause
+        if err := json.Unmarshal(raw, &c); err != nil {
+            return nil
+        }
+        list = append(list, c)
+    } else if err := json.Unmarshal(raw, &list); err != nil {
+        return nil
+    }
+
+    causes := make([]Cause, 0, len(list))
+    for _, c := range list {
+        causes = append(causes, Cause{
+            Code:        string(bytes.Trim(c.Code, `"`)),
+            Description: c.Description,
+        })
+    }
+
+    return causes
+}
+
 func (e *Error) Error() string {
     return fmt.Sprintf("%s", e.Message)
 }
+
+// HasCause reports whether MercadoPago listed code among the error causes.
+func (e *Error) HasCause(code string) bool {
+    for _, c := range e.Causes {
+        if c.Code == code {
+            return true
+        }
+    }
+    return false
+}
+
+// AsError finds the first *Error in err's chain.
+func AsError(err error) (*Error, bool) {
+    var e *Error
+    if errors.As(err, &e) {
+        return e, true
+    }
+    return nil, false
+}
+
+func IsNotFound(err error) bool {
+    return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether MercadoPago rejected the access token, either
+// because it is invalid or expired or because it lacks permission.
+func IsUnauthorized(err error) bool {
+    return hasStatusCode(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+func IsRateLimited(err error) bool {
+    return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsValidation reports whether MercadoPago rejected the request body or
+// parameters; the Causes of the error tell which ones.
+func IsValidation(err error) bool {
+    return hasStatusCode(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+func hasStatusCode(err error, statusCodes ...int) bool {
+    e, ok := AsError(err)
+    if !ok {
+        return false
+    }
+
+    for _, statusCode := range statusCodes {
+        if e.StatusCode == statusCode {
+            return true
+        }
+    }
+    return false
+}
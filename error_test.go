@@ -0,0 +1,93 @@
+package mercadopago
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreatePayment_ErrorEnvelope(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "400",
+        StatusCode: 400,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message": "Card Token not found", "error": "bad_request", "status": 400, "cause": [{"code": 2006, "description": "Card Token not found", "data": null}]}`))),
+    }
+    // When
+    _, err := g.CreatePayment("MY_ACCESS_TOKEN", newPayment())
+
+    // Then
+    require.EqualError(t, err, "Card Token not found")
+
+    var e *Error
+    require.True(t, errors.As(err, &e))
+    require.Equal(t, http.StatusBadRequest, e.StatusCode)
+    require.Equal(t, "bad_request", e.Code)
+    require.Equal(t, []Cause{{Code: "2006", Description: "Card Token not found"}}, e.Causes)
+    require.True(t, e.HasCause("2006"))
+    require.True(t, IsValidation(err))
+    require.False(t, IsNotFound(err))
+}
+
+func TestNewAPIError(t *testing.T) {
+    tt := []struct{
+        name string
+        body string
+        wantMessage string
+        wantCode string
+        wantCauses []Cause
+    }{
+        {
+            name: "cause sent as an object with a string code",
+            body: `{"message": "invalid access token", "error": "unauthorized", "status": 401, "cause": {"code": "401", "description": "invalid token"}}`,
+            wantMessage: "invalid access token",
+            wantCode: "unauthorized",
+            wantCauses: []Cause{{Code: "401", Description: "invalid token"}},
+        },
+        {
+            name: "no message falls back to the error code",
+            body: `{"error": "not_found", "status": 404}`,
+            wantMessage: "not_found",
+            wantCode: "not_found",
+        },
+        {
+            name: "body that isn't an envelope",
+            body: `<html>Bad Gateway</html>`,
+            wantMessage: "<html>Bad Gateway</html>",
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // When
+            e := newAPIError([]byte(tc.body), http.StatusBadGateway)
+
+            // Then
+            require.Equal(t, tc.wantMessage, e.Message)
+            require.Equal(t, tc.wantCode, e.Code)
+            require.Equal(t, tc.wantCauses, e.Causes)
+            require.Equal(t, tc.body, e.Body)
+        })
+    }
+}
+
+func TestErrorHelpers(t *testing.T) {
+    wrap := func(statusCode int) error {
+        return fmt.Errorf("get payment: %w", NewError("error", statusCode))
+    }
+
+    require.True(t, IsNotFound(wrap(http.StatusNotFound)))
+    require.True(t, IsUnauthorized(wrap(http.StatusUnauthorized)))
+    require.True(t, IsUnauthorized(wrap(http.StatusForbidden)))
+    require.True(t, IsRateLimited(wrap(http.StatusTooManyRequests)))
+    require.True(t, IsValidation(wrap(http.StatusUnprocessableEntity)))
+    require.False(t, IsNotFound(errors.New("random error")))
+    require.Equal(t, http.StatusTooManyRequests, getStatusCodeFromError(wrap(http.StatusTooManyRequests)))
+    require.Equal(t, http.StatusInternalServerError, getStatusCodeFromError(errors.New("random error")))
+}
@@ -1,22 +1,75 @@
 package mercadopago
 
 import (
+    "context"
+    "crypto/rand"
+    "crypto/subtle"
+    "encoding/base64"
     "encoding/json"
     "fmt"
     "github.com/go-playground/validator/v10"
+    "math"
     "net/http"
+    "strconv"
 )
 
-var _v = validator.New()
+var _v = newValidator()
 
+// newValidator returns the validator of inbound bodies, with the custom
+// validations used by the models.
+func newValidator() *validator.Validate {
+    v := validator.New()
+    v.RegisterValidation("identification", validateIdentification)
+    return v
+}
+
+// Service is implemented by Controller. Handler passes each inbound request's
+// context along, so MercadoPago calls are cancelled when the client goes away.
+// The operations without a context are kept from the first versions.
 type Service interface {
     GetAccessToken(clientID string, clientSecret string) (string, error)
-    CreatePreference(accessToken string, preference NewPreference) (string, string, error)
+    CreatePreference(accessToken string, preference NewPreference) (Preference, error)
     GetTotalPayments(accessToken string, status string) (int, error)
+    GetAccessTokenWithContext(ctx context.Context, clientID string, clientSecret string) (string, error)
+    CreatePreferenceWithContext(ctx context.Context, accessToken string, preference NewPreference) (Preference, error)
+    GetTotalPaymentsWithContext(ctx context.Context, accessToken string, status string) (int, error)
+    CreateRefundWithContext(ctx context.Context, accessToken string, paymentID string, amount float64) (Refund, error)
+    CreateFullRefundWithContext(ctx context.Context, accessToken string, paymentID string) (Refund, error)
+    AuthorizationURL(clientID string, redirectURI string, state string, pkce *PKCE) string
+    ExchangeCodeWithContext(ctx context.Context, clientID string, clientSecret string, code string, redirectURI string, codeVerifier string) (Token, error)
+}
+
+var _ Service = (*Controller)(nil)
+
+// OAuthConfig configures the OAuthAuthorize and OAuthCallback endpoints used
+// to link seller accounts.
+type OAuthConfig struct {
+    ClientID string
+    ClientSecret string
+    // RedirectURI is the address OAuthCallback is served on, as registered
+    // in the MercadoPago application.
+    RedirectURI string
+    // OnAuthorized receives the token of the linked seller, to be stored
+    // server side, and writes the response. It is required: the token is
+    // never written to the browser.
+    OnAuthorized func(w http.ResponseWriter, r *http.Request, token Token)
 }
 
+func (c OAuthConfig) configured() bool {
+    return c.ClientID != "" && c.OnAuthorized != nil
+}
+
+const (
+    _oauthStateCookie = "mp_oauth_state"
+    _oauthVerifierCookie = "mp_oauth_verifier"
+)
+
 type Handler struct {
     Service Service
+    OAuth OAuthConfig
+    // SiteID is the site of the seller, such as "MLB". When set,
+    // CreatePreference also rejects payer documents not used in it.
+    SiteID string
 }
 
 func NewHandler(service Service) *Handler{
@@ -45,7 +98,7 @@ func (h *Handler) GetAccessToken(w http.ResponseWriter, r *http.Request) {
         return
     }
 
-    accessToken, err := h.Service.GetAccessToken(clientID, clientSecret)
+    accessToken, err := h.Service.GetAccessTokenWithContext(r.Context(), clientID, clientSecret)
     if err != nil {
         w.WriteHeader(getStatusCodeFromError(err))
         fmt.Fprintf(w, "couldn't get access token: %v", err)
@@ -66,72 +119,223 @@ func (h *Handler) CreatePreference(w http.ResponseWriter, r *http.Request) {
 
     if err := _v.Struct(preference); err != nil {
         w.WriteHeader(http.StatusBadRequest)
-        fmt.Fprintf(w, fmt.Sprintf("validation error: %v", err))
+        fmt.Fprintf(w, "validation error: %v", err)
         return
     }
 
     for _, i := range preference.Items {
         if err := _v.Struct(i); err != nil {
             w.WriteHeader(http.StatusBadRequest)
-            fmt.Fprintf(w, fmt.Sprintf("validation error: %v", err))
+            fmt.Fprintf(w, "validation error: %v", err)
             return
         }
     }
 
+    if id := preference.Payer.Identification; h.SiteID != "" && id != (Identification{}) && !ValidIdentification(h.SiteID, id) {
+        w.WriteHeader(http.StatusBadRequest)
+        fmt.Fprintf(w, "validation error: %s identification is not valid in site %s", id.Type, h.SiteID)
+        return
+    }
 
     accessToken := r.Header.Get("access_token")
     if accessToken == "" {
         w.WriteHeader(http.StatusUnauthorized)
-        fmt.Fprintf(w, fmt.Sprintf("access token is required"))
+        fmt.Fprintf(w, "access token is required")
         return
     }
 
-    id, checkoutURL, err := h.Service.CreatePreference(accessToken, preference)
+    created, err := h.Service.CreatePreferenceWithContext(r.Context(), accessToken, preference)
     if err != nil {
         w.WriteHeader(getStatusCodeFromError(err))
         fmt.Fprintf(w, "couldn't create checkout: %v", err)
         return
     }
 
+    b, err := json.Marshal(created)
+    if err != nil {
+        w.WriteHeader(http.StatusInternalServerError)
+        fmt.Fprintf(w, "couldn't encode preference: %v", err)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
     w.WriteHeader(http.StatusOK)
-    fmt.Fprintf(w, fmt.Sprintf("%s", id))
-    fmt.Fprintf(w, fmt.Sprintf("%s", checkoutURL))
+    w.Write(b)
 }
 
 func (h *Handler) GetTotalPayments(w http.ResponseWriter, r *http.Request) {
     accessToken := r.Header.Get("access_token")
     if accessToken == "" {
         w.WriteHeader(http.StatusUnauthorized)
-        fmt.Fprintf(w, fmt.Sprintf("access token is required"))
+        fmt.Fprintf(w, "access token is required")
         return
     }
 
     status := r.URL.Query().Get("status")
     if status == "" {
         w.WriteHeader(http.StatusBadRequest)
-        fmt.Fprintf(w, fmt.Sprintf("status is required"))
+        fmt.Fprintf(w, "status is required")
         return
     }
 
     if status != "approved" && status != "rejected" && status != "pending" {
         w.WriteHeader(http.StatusBadRequest)
-        fmt.Fprintf(w, fmt.Sprintf("invalid status: got: %s, want: approved, rejected or pending", status))
+        fmt.Fprintf(w, "invalid status: got: %s, want: approved, rejected or pending", status)
         return
     }
 
-    total, err := h.Service.GetTotalPayments(accessToken, status)
+    total, err := h.Service.GetTotalPaymentsWithContext(r.Context(), accessToken, status)
     if err != nil {
         w.WriteHeader(getStatusCodeFromError(err))
-        fmt.Fprintf(w, fmt.Sprintf("couldn't get total payments: %v", err))
+        fmt.Fprintf(w, "couldn't get total payments: %v", err)
+        return
+    }
+
+    w.WriteHeader(http.StatusOK)
+    fmt.Fprintf(w, "total payments: %d", total)
+}
+
+func (h *Handler) CreateRefund(w http.ResponseWriter, r *http.Request) {
+    accessToken := r.Header.Get("access_token")
+    if accessToken == "" {
+        w.WriteHeader(http.StatusUnauthorized)
+        fmt.Fprintf(w, "access token is required")
+        return
+    }
+
+    paymentID := r.URL.Query().Get("payment_id")
+    if paymentID == "" {
+        w.WriteHeader(http.StatusBadRequest)
+        fmt.Fprintf(w, "payment id is required")
+        return
+    }
+
+    // Without an amount the whole payment is refunded.
+    var refund Refund
+    var err error
+    if a := r.URL.Query().Get("amount"); a != "" {
+        amount, perr := strconv.ParseFloat(a, 64)
+        if perr != nil || amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+            w.WriteHeader(http.StatusBadRequest)
+            fmt.Fprintf(w, "invalid amount: %s", a)
+            return
+        }
+        refund, err = h.Service.CreateRefundWithContext(r.Context(), accessToken, paymentID, amount)
+    } else {
+        refund, err = h.Service.CreateFullRefundWithContext(r.Context(), accessToken, paymentID)
+    }
+    if err != nil {
+        w.WriteHeader(getStatusCodeFromError(err))
+        fmt.Fprintf(w, "couldn't create refund: %v", err)
+        return
+    }
+
+    b, err := json.Marshal(refund)
+    if err != nil {
+        w.WriteHeader(http.StatusInternalServerError)
+        fmt.Fprintf(w, "couldn't encode refund: %v", err)
         return
     }
 
+    w.Header().Set("Content-Type", "application/json")
     w.WriteHeader(http.StatusOK)
-    fmt.Fprintf(w, fmt.Sprintf("total payments: %d", total))
+    w.Write(b)
+}
+
+// OAuthAuthorize redirects the seller to MercadoPago to link their account.
+// The state and PKCE verifier are kept in short-lived cookies and checked by
+// OAuthCallback.
+func (h *Handler) OAuthAuthorize(w http.ResponseWriter, r *http.Request) {
+    if !h.OAuth.configured() {
+        w.WriteHeader(http.StatusInternalServerError)
+        fmt.Fprintf(w, "oauth is not configured")
+        return
+    }
+
+    state := make([]byte, 24)
+    if _, err := rand.Read(state); err != nil {
+        w.WriteHeader(http.StatusInternalServerError)
+        fmt.Fprintf(w, "couldn't generate state: %v", err)
+        return
+    }
+
+    pkce, err := NewPKCE()
+    if err != nil {
+        w.WriteHeader(http.StatusInternalServerError)
+        fmt.Fprintf(w, "couldn't generate pkce: %v", err)
+        return
+    }
+
+    encodedState := base64.RawURLEncoding.EncodeToString(state)
+    setOAuthCookie(w, r, _oauthStateCookie, encodedState, 600)
+    setOAuthCookie(w, r, _oauthVerifierCookie, pkce.Verifier, 600)
+
+    http.Redirect(w, r, h.Service.AuthorizationURL(h.OAuth.ClientID, h.OAuth.RedirectURI, encodedState, &pkce), http.StatusFound)
+}
+
+// OAuthCallback is the redirect URI of the authorization flow: it checks the
+// state against the one set by OAuthAuthorize and exchanges the code for the
+// seller's token.
+func (h *Handler) OAuthCallback(w http.ResponseWriter, r *http.Request) {
+    if !h.OAuth.configured() {
+        w.WriteHeader(http.StatusInternalServerError)
+        fmt.Fprintf(w, "oauth is not configured")
+        return
+    }
+
+    if e := r.URL.Query().Get("error"); e != "" {
+        w.WriteHeader(http.StatusBadRequest)
+        fmt.Fprintf(w, "authorization failed: %s", e)
+        return
+    }
+
+    code := r.URL.Query().Get("code")
+    if code == "" {
+        w.WriteHeader(http.StatusBadRequest)
+        fmt.Fprintf(w, "code is required")
+        return
+    }
+
+    state := r.URL.Query().Get("state")
+    cookie, err := r.Cookie(_oauthStateCookie)
+    if state == "" || err != nil || subtle.ConstantTimeCompare([]byte(state), []byte(cookie.Value)) != 1 {
+        w.WriteHeader(http.StatusBadRequest)
+        fmt.Fprintf(w, "invalid state")
+        return
+    }
+
+    var codeVerifier string
+    if cookie, err := r.Cookie(_oauthVerifierCookie); err == nil {
+        codeVerifier = cookie.Value
+    }
+
+    setOAuthCookie(w, r, _oauthStateCookie, "", -1)
+    setOAuthCookie(w, r, _oauthVerifierCookie, "", -1)
+
+    token, err := h.Service.ExchangeCodeWithContext(r.Context(), h.OAuth.ClientID, h.OAuth.ClientSecret, code, h.OAuth.RedirectURI, codeVerifier)
+    if err != nil {
+        w.WriteHeader(getStatusCodeFromError(err))
+        fmt.Fprintf(w, "couldn't exchange code: %v", err)
+        return
+    }
+
+    h.OAuth.OnAuthorized(w, r, token)
+}
+
+func setOAuthCookie(w http.ResponseWriter, r *http.Request, name string, value string, maxAge int) {
+    http.SetCookie(w, &http.Cookie{
+        Name:     name,
+        Value:    value,
+        Path:     "/",
+        MaxAge:   maxAge,
+        HttpOnly: true,
+        Secure:   r.TLS != nil,
+        SameSite: http.SameSiteLaxMode,
+    })
 }
 
 func getStatusCodeFromError(err error) int {
-    e, ok := err.(*Error)
+    e, ok := AsError(err)
     if !ok {
         return http.StatusInternalServerError
     }
@@ -2,38 +2,74 @@ package mercadopago
 
 import (
     "bytes"
+    "context"
     "errors"
     "fmt"
     "github.com/stretchr/testify/require"
     "io/ioutil"
     "net/http"
     "net/http/httptest"
+    "net/url"
     "testing"
 )
 
 type ServiceStub struct {
     accessToken string
-    checkout string
+    preference Preference
     totalPayments int
+    refund Refund
+    token Token
+    code string
+    codeVerifier string
     err error
 }
 
-func (s *ServiceStub) GetAccessToken(_ string, _ string) (string, error) {
+func (s *ServiceStub) GetAccessToken(clientID string, clientSecret string) (string, error) {
+    return s.GetAccessTokenWithContext(context.Background(), clientID, clientSecret)
+}
+
+func (s *ServiceStub) CreatePreference(accessToken string, preference NewPreference) (Preference, error) {
+    return s.CreatePreferenceWithContext(context.Background(), accessToken, preference)
+}
+
+func (s *ServiceStub) GetTotalPayments(accessToken string, status string) (int, error) {
+    return s.GetTotalPaymentsWithContext(context.Background(), accessToken, status)
+}
+
+func (s *ServiceStub) GetAccessTokenWithContext(_ context.Context, _ string, _ string) (string, error) {
     return s.accessToken, s.err
 }
 
-func (s *ServiceStub) CreatePreference(_ string, _ NewPreference) (string, string, error) {
-    return s.id, s.checkout, s.err
+func (s *ServiceStub) CreatePreferenceWithContext(_ context.Context, _ string, _ NewPreference) (Preference, error) {
+    return s.preference, s.err
 }
 
 /*func (s *ServiceStub) GetPaymentsSearch(_ string, _ string) (int, error) {
     return s.totalPayments, s.err
 }*/
 
-func (s *ServiceStub) GetTotalPayments(_ string, _ string) (int, error) {
+func (s *ServiceStub) GetTotalPaymentsWithContext(_ context.Context, _ string, _ string) (int, error) {
     return s.totalPayments, s.err
 }
 
+func (s *ServiceStub) CreateRefundWithContext(_ context.Context, _ string, _ string, _ float64) (Refund, error) {
+    return s.refund, s.err
+}
+
+func (s *ServiceStub) CreateFullRefundWithContext(_ context.Context, _ string, _ string) (Refund, error) {
+    return s.refund, s.err
+}
+
+func (s *ServiceStub) AuthorizationURL(clientID string, redirectURI string, state string, pkce *PKCE) string {
+    return (&Gateway{}).AuthorizationURL(clientID, redirectURI, state, pkce)
+}
+
+func (s *ServiceStub) ExchangeCodeWithContext(_ context.Context, _ string, _ string, code string, _ string, codeVerifier string) (Token, error) {
+    s.code = code
+    s.codeVerifier = codeVerifier
+    return s.token, s.err
+}
+
 func TestHandler_GetAccessToken(t *testing.T) {
     // Given
     h := NewHandler(&ServiceStub{
@@ -179,7 +215,11 @@ func TestHandler_GetAccessToken_Error(t *testing.T) {
 func TestHandler_CreatePreference(t *testing.T) {
     // Given
     h := NewHandler(&ServiceStub{
-        checkout: "https://mercadopago.com/MY_CHECKOUT_PATH",
+        preference: Preference{
+            Id:                 "123-abc",
+            Init_point:         "https://mercadopago.com/MY_CHECKOUT_PATH",
+            Sandbox_init_point: "https://sandbox.mercadopago.com/MY_CHECKOUT_PATH",
+        },
     })
     body := []byte(`{
         "items": [
@@ -192,14 +232,14 @@ func TestHandler_CreatePreference(t *testing.T) {
             }
         ],
         "payer": {
-            "name": "Mateo",
+            "first_name": "Mateo",
             "email": "mateo.ferrari@gmail.com",
             "phone": {
                 "number": "11111111"
             },
             "address": {
-                "street": "posta",
-                "number": 4789
+                "street_name": "posta",
+                "street_number": 4789
             },
             "date_created": "14-06-2020"
         }
@@ -227,14 +267,17 @@ func TestHandler_CreatePreference(t *testing.T) {
     }
 
     // Then
-    require.Equal(t, "https://mercadopago.com/MY_CHECKOUT_PATH", string(b))
+    require.Contains(t, string(b), `"id":"123-abc"`)
+    require.Contains(t, string(b), `"init_point":"https://mercadopago.com/MY_CHECKOUT_PATH"`)
+    require.Contains(t, string(b), `"sandbox_init_point":"https://sandbox.mercadopago.com/MY_CHECKOUT_PATH"`)
+    require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
     require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
 func TestHandler_CreatePreference_UnprocessableEntity_Error(t *testing.T) {
     // Given
     h := NewHandler(&ServiceStub{
-        checkout: "https://mercadopago.com/MY_CHECKOUT_PATH",
+        preference: Preference{Init_point: "https://mercadopago.com/MY_CHECKOUT_PATH"},
     })
     body := []byte(`{
         "items": [
@@ -247,14 +290,14 @@ func TestHandler_CreatePreference_UnprocessableEntity_Error(t *testing.T) {
             }
         ],
         "payer": {
-            "name": "Mateo",
+            "first_name": "Mateo",
             "email": "mateo.ferrari@gmail.com",
             "phone": {
                 "number": "11111111"
             },
             "address": {
-                "street": "posta",
-                "number": 4789
+                "street_name": "posta",
+                "street_number": 4789
             },
             "date_created": "14-06-2020"
         }
@@ -282,13 +325,14 @@ func TestHandler_CreatePreference_UnprocessableEntity_Error(t *testing.T) {
     }
 
     // Then
-    require.Equal(t, "couldn't decode body: json: cannot unmarshal string into Go struct field Item.items.quantity of type int", string(b))
+    require.Equal(t, "couldn't decode body: json: cannot unmarshal string into Go struct field NewPreference.items.0.quantity of type int", string(b))
     require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
 }
 
 func TestHandler_CreatePreference_BadRequest_Error(t *testing.T) {
     tt := []struct{
         name string
+        siteID string
         body []byte
         wantError string
     }{
@@ -296,14 +340,14 @@ func TestHandler_CreatePreference_BadRequest_Error(t *testing.T) {
             name: "missing items field",
             body: []byte(`{
                     "payer": {
-                        "name": "Mateo",
+                        "first_name": "Mateo",
                         "email": "mateo.ferrari@gmail.com",
                         "phone": {
                             "number": "11111111"
                         },
                         "address": {
-                            "street": "posta",
-                            "number": 4789
+                            "street_name": "posta",
+                            "street_number": 4789
                         },
                         "date_created": "14-06-2020"
                     }
@@ -315,14 +359,14 @@ func TestHandler_CreatePreference_BadRequest_Error(t *testing.T) {
             body: []byte(`{
                     "items": [],
                     "payer": {
-                        "name": "Mateo",
+                        "first_name": "Mateo",
                         "email": "mateo.ferrari@gmail.com",
                         "phone": {
                             "number": "11111111"
                         },
                         "address": {
-                            "street": "posta",
-                            "number": 4789
+                            "street_name": "posta",
+                            "street_number": 4789
                         },
                         "date_created": "14-06-2020"
                     }
@@ -342,10 +386,10 @@ func TestHandler_CreatePreference_BadRequest_Error(t *testing.T) {
                                 }
                     ]
             }`),
-            wantError: "validation error: Key: 'NewPreference.Payer.Name' Error:Field validation for 'Name' failed on the 'required' tag\nKey: 'NewPreference.Payer.Email' Error:Field validation for 'Email' failed on the 'required' tag\nKey: 'NewPreference.Payer.Phone.Number' Error:Field validation for 'Number' failed on the 'required' tag\nKey: 'NewPreference.Payer.Address.Street' Error:Field validation for 'Street' failed on the 'required' tag\nKey: 'NewPreference.Payer.Address.Number' Error:Field validation for 'Number' failed on the 'required' tag\nKey: 'NewPreference.Payer.CreatedAt' Error:Field validation for 'CreatedAt' failed on the 'required' tag",
+            wantError: "validation error: Key: 'NewPreference.Payer.Email' Error:Field validation for 'Email' failed on the 'required' tag\nKey: 'NewPreference.Payer.Phone.Number' Error:Field validation for 'Number' failed on the 'required' tag\nKey: 'NewPreference.Payer.CreatedAt' Error:Field validation for 'CreatedAt' failed on the 'required' tag",
         },
         {
-            name: "missing name inside payer field",
+            name: "missing email inside payer field",
             body: []byte(`{
                     "items": [
                         {
@@ -357,18 +401,18 @@ func TestHandler_CreatePreference_BadRequest_Error(t *testing.T) {
                         }
                     ],
                     "payer": {
-                        "email": "mateo.ferrari@gmail.com",
+                        "first_name": "Mateo",
                         "phone": {
                             "number": "11111111"
                         },
                         "address": {
-                            "street": "posta",
-                            "number": 4789
+                            "street_name": "posta",
+                            "street_number": 4789
                         },
                         "date_created": "14-06-2020"
                     }
             }`),
-            wantError: "validation error: Key: 'NewPreference.Payer.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+            wantError: "validation error: Key: 'NewPreference.Payer.Email' Error:Field validation for 'Email' failed on the 'required' tag",
         },
         {
             name: "missing unit_price inside items field",
@@ -382,26 +426,86 @@ func TestHandler_CreatePreference_BadRequest_Error(t *testing.T) {
                         }
                     ],
                     "payer": {
-                        "name": "Mateo",
+                        "first_name": "Mateo",
                         "email": "mateo.ferrari@gmail.com",
                         "phone": {
                             "number": "11111111"
                         },
                         "address": {
-                            "street": "posta",
-                            "number": 4789
+                            "street_name": "posta",
+                            "street_number": 4789
                         },
                         "date_created": "14-06-2020"
                     }
             }`),
             wantError: "validation error: Key: 'Item.UnitPrice' Error:Field validation for 'UnitPrice' failed on the 'required' tag",
         },
+        {
+            name: "invalid CPF inside payer field",
+            body: []byte(`{
+                    "items": [
+                        {
+                            "title": "Libro Sherlock Holmes 1era edicion",
+                            "quantity": 1,
+                            "unit_price": 150.70
+                        }
+                    ],
+                    "payer": {
+                        "first_name": "Mateo",
+                        "email": "mateo.ferrari@gmail.com",
+                        "phone": {
+                            "number": "11111111"
+                        },
+                        "identification": {
+                            "type": "CPF",
+                            "number": "12345678900"
+                        },
+                        "address": {
+                            "street_name": "posta",
+                            "street_number": 4789
+                        },
+                        "date_created": "14-06-2020"
+                    }
+            }`),
+            wantError: "validation error: Key: 'NewPreference.Payer.Identification' Error:Field validation for 'Identification' failed on the 'identification' tag",
+        },
+        {
+            name: "CPF for a seller outside Brazil",
+            siteID: "MLA",
+            body: []byte(`{
+                    "items": [
+                        {
+                            "title": "Libro Sherlock Holmes 1era edicion",
+                            "quantity": 1,
+                            "unit_price": 150.70
+                        }
+                    ],
+                    "payer": {
+                        "first_name": "Mateo",
+                        "email": "mateo.ferrari@gmail.com",
+                        "phone": {
+                            "number": "11111111"
+                        },
+                        "identification": {
+                            "type": "CPF",
+                            "number": "19119119100"
+                        },
+                        "address": {
+                            "street_name": "posta",
+                            "street_number": 4789
+                        },
+                        "date_created": "14-06-2020"
+                    }
+            }`),
+            wantError: "validation error: CPF identification is not valid in site MLA",
+        },
     }
 
     for _, tc := range tt {
         t.Run(tc.name, func(t *testing.T) {
             // Given
             h := NewHandler(&ServiceStub{})
+            h.SiteID = tc.siteID
             ts := httptest.NewServer(http.HandlerFunc(h.CreatePreference))
             defer ts.Close()
 
@@ -446,14 +550,14 @@ func TestHandler_CreatePreference_Unauthorized_Error(t *testing.T) {
             }
         ],
         "payer": {
-            "name": "Mateo",
+            "first_name": "Mateo",
             "email": "mateo.ferrari@gmail.com",
             "phone": {
                 "number": "11111111"
             },
             "address": {
-                "street": "posta",
-                "number": 4789
+                "street_name": "posta",
+                "street_number": 4789
             },
             "date_created": "14-06-2020"
         }
@@ -484,6 +588,7 @@ func TestHandler_CreatePreference_Unauthorized_Error(t *testing.T) {
 func TestHandler_CreatePreference_ClientError(t *testing.T) {
     tt := []struct{
         name string
+        preference Preference
         err error
         wantError string
         wantErrorStatusCode int
@@ -512,12 +617,19 @@ func TestHandler_CreatePreference_ClientError(t *testing.T) {
             wantError: "couldn't create checkout: random error",
             wantErrorStatusCode: http.StatusInternalServerError,
         },
+        {
+            name: "couldn't encode preference",
+            preference: Preference{Metadata: map[string]interface{}{"channel": make(chan int)}},
+            wantError: "couldn't encode preference: json: unsupported type: chan int",
+            wantErrorStatusCode: http.StatusInternalServerError,
+        },
     }
 
     for _, tc := range tt {
         t.Run(tc.name, func(t *testing.T) {
             // Given
             h := NewHandler(&ServiceStub{
+                preference: tc.preference,
                 err: tc.err,
             })
             body := []byte(`{
@@ -531,14 +643,14 @@ func TestHandler_CreatePreference_ClientError(t *testing.T) {
                         }
                     ],
                     "payer": {
-                        "name": "Mateo",
+                        "first_name": "Mateo",
                         "email": "mateo.ferrari@gmail.com",
                         "phone": {
                             "number": "11111111"
                         },
                         "address": {
-                            "street": "posta",
-                            "number": 4789
+                            "street_name": "posta",
+                            "street_number": 4789
                         },
                         "date_created": "14-06-2020"
                     }
@@ -665,3 +777,314 @@ func TestHandler_GetTotalPayments_BadRequest_Error(t *testing.T) {
     require.Equal(t, "invalid status: got: random, want: approved, rejected or pending", string(b))
     require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
+
+func TestHandler_CreateRefund(t *testing.T) {
+    // Given
+    h := NewHandler(&ServiceStub{
+        refund: Refund{Id: 1, Payment_id: 123, Amount: 10.5, Status: RefundStatusApproved},
+    })
+    ts := httptest.NewServer(http.HandlerFunc(h.CreateRefund))
+    defer ts.Close()
+
+    // When
+    req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/refunds?payment_id=123&amount=10.5", ts.URL), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    req.Header.Add("access_token", "MY_ACCESS_TOKEN")
+
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer resp.Body.Close()
+
+    b, err := ioutil.ReadAll(resp.Body)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Then
+    require.Contains(t, string(b), `"payment_id":123`)
+    require.Contains(t, string(b), `"status":"approved"`)
+    require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHandler_CreateRefund_Error(t *testing.T) {
+    tt := []struct{
+        name string
+        query string
+        accessToken string
+        refund Refund
+        err error
+        wantError string
+        wantErrorStatusCode int
+    }{
+        {
+            name: "missing access token",
+            query: "payment_id=123",
+            wantError: "access token is required",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "missing payment id",
+            query: "amount=10",
+            accessToken: "MY_ACCESS_TOKEN",
+            wantError: "payment id is required",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "invalid amount",
+            query: "payment_id=123&amount=-1",
+            accessToken: "MY_ACCESS_TOKEN",
+            wantError: "invalid amount: -1",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "NaN amount",
+            query: "payment_id=123&amount=NaN",
+            accessToken: "MY_ACCESS_TOKEN",
+            wantError: "invalid amount: NaN",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "infinite amount",
+            query: "payment_id=123&amount=Inf",
+            accessToken: "MY_ACCESS_TOKEN",
+            wantError: "invalid amount: Inf",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "not found from server",
+            query: "payment_id=123",
+            accessToken: "MY_ACCESS_TOKEN",
+            err: NewError("payment not found", http.StatusNotFound),
+            wantError: "couldn't create refund: payment not found",
+            wantErrorStatusCode: http.StatusNotFound,
+        },
+        {
+            name: "couldn't encode refund",
+            query: "payment_id=123",
+            accessToken: "MY_ACCESS_TOKEN",
+            refund: Refund{Metadata: map[string]interface{}{"channel": make(chan int)}},
+            wantError: "couldn't encode refund: json: unsupported type: chan int",
+            wantErrorStatusCode: http.StatusInternalServerError,
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // Given
+            h := NewHandler(&ServiceStub{
+                refund: tc.refund,
+                err: tc.err,
+            })
+            ts := httptest.NewServer(http.HandlerFunc(h.CreateRefund))
+            defer ts.Close()
+
+            // When
+            req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/refunds?%s", ts.URL, tc.query), nil)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            if tc.accessToken != "" {
+                req.Header.Add("access_token", tc.accessToken)
+            }
+
+            resp, err := http.DefaultClient.Do(req)
+            if err != nil {
+                t.Fatal(err)
+            }
+            defer resp.Body.Close()
+
+            b, err := ioutil.ReadAll(resp.Body)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            // Then
+            require.Equal(t, tc.wantError, string(b))
+            require.Equal(t, tc.wantErrorStatusCode, resp.StatusCode)
+        })
+    }
+}
+
+func newOAuthConfig(onAuthorized func(w http.ResponseWriter, r *http.Request, token Token)) OAuthConfig {
+    if onAuthorized == nil {
+        onAuthorized = func(w http.ResponseWriter, _ *http.Request, _ Token) {
+            w.WriteHeader(http.StatusOK)
+        }
+    }
+    return OAuthConfig{ClientID: "APP_ID", ClientSecret: "SECRET", RedirectURI: "https://example.com/callback", OnAuthorized: onAuthorized}
+}
+
+func TestHandler_OAuthCallback(t *testing.T) {
+    // Given
+    s := &ServiceStub{
+        token: Token{AccessToken: "APP_USR-SELLER", RefreshToken: "TG-REFRESH", UserID: 12345, PublicKey: "APP_USR-PUBLIC", LiveMode: true},
+    }
+    var stored Token
+    h := NewHandler(s)
+    h.OAuth = newOAuthConfig(func(w http.ResponseWriter, _ *http.Request, token Token) {
+        stored = token
+        fmt.Fprintf(w, "linked seller %d", token.UserID)
+    })
+    ts := httptest.NewServer(http.HandlerFunc(h.OAuthCallback))
+    defer ts.Close()
+
+    // When
+    req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/callback?code=TG-CODE&state=STATE", ts.URL), nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    req.AddCookie(&http.Cookie{Name: "mp_oauth_state", Value: "STATE"})
+    req.AddCookie(&http.Cookie{Name: "mp_oauth_verifier", Value: "VERIFIER"})
+
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer resp.Body.Close()
+
+    b, err := ioutil.ReadAll(resp.Body)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Then
+    require.Equal(t, http.StatusOK, resp.StatusCode)
+    require.Equal(t, "linked seller 12345", string(b))
+    require.Equal(t, "APP_USR-SELLER", stored.AccessToken)
+    require.Equal(t, "TG-REFRESH", stored.RefreshToken)
+    require.Equal(t, "TG-CODE", s.code)
+    require.Equal(t, "VERIFIER", s.codeVerifier)
+}
+
+func TestHandler_OAuthCallback_NotConfigured(t *testing.T) {
+    // Given
+    h := NewHandler(&ServiceStub{
+        token: Token{AccessToken: "APP_USR-SELLER"},
+    })
+    h.OAuth = OAuthConfig{ClientID: "APP_ID", ClientSecret: "SECRET", RedirectURI: "https://example.com/callback"}
+    w := httptest.NewRecorder()
+    r := httptest.NewRequest(http.MethodGet, "/callback?code=TG-CODE&state=STATE", nil)
+    r.AddCookie(&http.Cookie{Name: "mp_oauth_state", Value: "STATE"})
+
+    // When
+    h.OAuthCallback(w, r)
+
+    // Then
+    require.Equal(t, http.StatusInternalServerError, w.Code)
+    require.Equal(t, "oauth is not configured", w.Body.String())
+    require.NotContains(t, w.Body.String(), "APP_USR-SELLER")
+}
+
+func TestHandler_OAuthCallback_Error(t *testing.T) {
+    tt := []struct{
+        name string
+        query string
+        stateCookie string
+        err error
+        wantError string
+        wantErrorStatusCode int
+    }{
+        {
+            name: "authorization denied",
+            query: "error=access_denied",
+            wantError: "authorization failed: access_denied",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "missing code",
+            query: "state=STATE",
+            stateCookie: "STATE",
+            wantError: "code is required",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "missing state cookie",
+            query: "code=TG-CODE&state=STATE",
+            wantError: "invalid state",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "state mismatch",
+            query: "code=TG-CODE&state=FORGED",
+            stateCookie: "STATE",
+            wantError: "invalid state",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "invalid grant from server",
+            query: "code=TG-CODE&state=STATE",
+            stateCookie: "STATE",
+            err: NewError("invalid_grant", http.StatusBadRequest),
+            wantError: "couldn't exchange code: invalid_grant",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // Given
+            h := NewHandler(&ServiceStub{
+                err: tc.err,
+            })
+            h.OAuth = newOAuthConfig(nil)
+            ts := httptest.NewServer(http.HandlerFunc(h.OAuthCallback))
+            defer ts.Close()
+
+            // When
+            req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/callback?%s", ts.URL, tc.query), nil)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            if tc.stateCookie != "" {
+                req.AddCookie(&http.Cookie{Name: "mp_oauth_state", Value: tc.stateCookie})
+            }
+
+            resp, err := http.DefaultClient.Do(req)
+            if err != nil {
+                t.Fatal(err)
+            }
+            defer resp.Body.Close()
+
+            b, err := ioutil.ReadAll(resp.Body)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            // Then
+            require.Equal(t, tc.wantError, string(b))
+            require.Equal(t, tc.wantErrorStatusCode, resp.StatusCode)
+        })
+    }
+}
+
+func TestHandler_OAuthAuthorize(t *testing.T) {
+    // Given
+    h := NewHandler(&ServiceStub{})
+    h.OAuth = newOAuthConfig(nil)
+    w := httptest.NewRecorder()
+
+    // When
+    h.OAuthAuthorize(w, httptest.NewRequest(http.MethodGet, "/authorize", nil))
+
+    // Then
+    resp := w.Result()
+    require.Equal(t, http.StatusFound, resp.StatusCode)
+
+    cookies := map[string]string{}
+    for _, c := range resp.Cookies() {
+        cookies[c.Name] = c.Value
+    }
+
+    location, err := url.Parse(resp.Header.Get("Location"))
+    require.NoError(t, err)
+    require.Equal(t, cookies["mp_oauth_state"], location.Query().Get("state"))
+    require.Equal(t, newPKCE(cookies["mp_oauth_verifier"]).Challenge, location.Query().Get("code_challenge"))
+}
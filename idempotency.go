@@ -0,0 +1,63 @@
+package mercadopago
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+)
+
+type idempotencyKeyContextKey struct{}
+
+type operationIDContextKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx that makes mutating Gateway
+// calls send key as their X-Idempotency-Key header, so MercadoPago processes a
+// repeated call only once.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// ContextWithOperationID returns a copy of ctx that makes mutating Gateway
+// calls send an X-Idempotency-Key derived from operationID (see
+// IdempotencyKey). Workers retrying the same job with the same operation ID
+// therefore never create a payment or preference twice.
+func ContextWithOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, operationIDContextKey{}, operationID)
+}
+
+// IdempotencyKey deterministically derives an idempotency key, formatted as a
+// UUID, from an operation ID and the request method, path and body. The same
+// operation ID yields different keys for different endpoints, and for
+// different changes to the same resource, such as capturing and then
+// cancelling a payment.
+func IdempotencyKey(operationID string, method string, path string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s %s\n", operationID, method, path)
+	h.Write(body)
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// setIdempotencyKey sets the X-Idempotency-Key header of mutating requests
+// from the key or the operation ID carried by the request context. body is
+// the JSON body of the request, if any.
+func setIdempotencyKey(req *http.Request, body []byte) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return
+	}
+
+	ctx := req.Context()
+	if key, _ := ctx.Value(idempotencyKeyContextKey{}).(string); key != "" {
+		req.Header.Set("X-Idempotency-Key", key)
+		return
+	}
+
+	if operationID, _ := ctx.Value(operationIDContextKey{}).(string); operationID != "" {
+		req.Header.Set("X-Idempotency-Key", IdempotencyKey(operationID, req.Method, req.URL.Path, body))
+	}
+}
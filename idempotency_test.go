@@ -0,0 +1,102 @@
+package mercadopago
+
+import (
+    "context"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "testing"
+    "time"
+)
+
+func TestGateway_CreatePayment_IdempotencyKey(t *testing.T) {
+    // Given
+    c := &ClientStub{resp: newResponse(http.StatusCreated, `{"id": 123}`)}
+    g := NewGateway(c)
+    ctx := ContextWithIdempotencyKey(context.Background(), "MY_KEY")
+
+    // When
+    _, err := g.CreatePaymentWithContext(ctx, "MY_ACCESS_TOKEN", newPayment())
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "MY_KEY", c.req.Header.Get("X-Idempotency-Key"))
+}
+
+func TestGateway_CreatePayment_OperationID_ReusedAcrossRetries(t *testing.T) {
+    // Given
+    var keys []string
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusServiceUnavailable, ``),
+            newResponse(http.StatusCreated, `{"id": 123}`),
+        },
+    }
+    g := NewGateway(clientFunc(func(req *http.Request) (*http.Response, error) {
+        keys = append(keys, req.Header.Get("X-Idempotency-Key"))
+        return c.Do(req)
+    }), WithRetry(RetryPolicy{BaseDelay: time.Millisecond}))
+    ctx := ContextWithOperationID(context.Background(), "order-42")
+
+    // When
+    payment, err := g.CreatePaymentWithContext(ctx, "MY_ACCESS_TOKEN", newPayment())
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 123, payment.Id)
+    require.Len(t, keys, 2)
+    require.Equal(t, IdempotencyKey("order-42", http.MethodPost, "/v1/payments", []byte(c.bodies[0])), keys[0])
+    require.Equal(t, keys[0], keys[1])
+}
+
+func TestGateway_OperationID_DistinctKeysForSamePath(t *testing.T) {
+    // Given
+    var keys []string
+    g := NewGateway(clientFunc(func(req *http.Request) (*http.Response, error) {
+        keys = append(keys, req.Header.Get("X-Idempotency-Key"))
+        return newResponse(http.StatusOK, `{"id": 123}`), nil
+    }))
+    ctx := ContextWithOperationID(context.Background(), "order-42")
+
+    // When
+    _, err := g.CapturePaymentWithContext(ctx, "MY_ACCESS_TOKEN", "123")
+    require.NoError(t, err)
+    _, err = g.CancelPaymentWithContext(ctx, "MY_ACCESS_TOKEN", "123")
+    require.NoError(t, err)
+
+    // Then
+    require.Len(t, keys, 2)
+    require.NotEmpty(t, keys[0])
+    require.NotEmpty(t, keys[1])
+    require.NotEqual(t, keys[0], keys[1])
+}
+
+func TestGateway_GetPayments_NoIdempotencyKey(t *testing.T) {
+    // Given
+    c := &ClientStub{resp: newResponse(http.StatusOK, `{"id": 123}`)}
+    g := NewGateway(c)
+    ctx := ContextWithIdempotencyKey(context.Background(), "MY_KEY")
+
+    // When
+    _, err := g.GetPaymentsWithContext(ctx, "MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Empty(t, c.req.Header.Get("X-Idempotency-Key"))
+}
+
+func TestIdempotencyKey(t *testing.T) {
+    body := []byte(`{"transaction_amount": 100}`)
+    key := IdempotencyKey("order-42", http.MethodPost, "/v1/payments", body)
+
+    require.Equal(t, key, IdempotencyKey("order-42", http.MethodPost, "/v1/payments", body))
+    require.NotEqual(t, key, IdempotencyKey("order-43", http.MethodPost, "/v1/payments", body))
+    require.NotEqual(t, key, IdempotencyKey("order-42", http.MethodPost, "/checkout/preferences", body))
+    require.NotEqual(t, key, IdempotencyKey("order-42", http.MethodPost, "/v1/payments", []byte(`{"transaction_amount": 200}`)))
+    require.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, key)
+}
+
+type clientFunc func(req *http.Request) (*http.Response, error)
+
+func (f clientFunc) Do(req *http.Request) (*http.Response, error) {
+    return f(req)
+}
@@ -0,0 +1,247 @@
+package mercadopago
+
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// IdentificationType is a document type accepted in a site, as returned by
+// /v1/identification_types.
+type IdentificationType struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Min_length int    `json:"min_length"`
+	Max_length int    `json:"max_length"`
+}
+
+func (g *Gateway) GetIdentificationTypes(accessToken string) ([]IdentificationType, error) {
+	return g.GetIdentificationTypesWithContext(context.Background(), accessToken)
+}
+
+func (g *Gateway) GetIdentificationTypesWithContext(ctx context.Context, accessToken string) (types []IdentificationType, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/identification_types", accessToken, nil, &types)
+	return
+}
+
+var (
+	_curpRegexp = regexp.MustCompile(`^[A-Z][AEIOUX][A-Z]{2}\d{6}[HMX][A-Z]{5}[A-Z0-9]\d$`)
+	_rfcRegexp  = regexp.MustCompile(`^[A-ZÑ&]{3,4}\d{6}[A-Z0-9]{3}$`)
+)
+
+// _identificationSites lists the sites each document type is used in.
+var _identificationSites = map[string][]string{
+	"CPF":       {"MLB"},
+	"CNPJ":      {"MLB"},
+	"DNI":       {"MLA", "MPE"},
+	"CUIT":      {"MLA"},
+	"CUIL":      {"MLA"},
+	"LC":        {"MLA"},
+	"LE":        {"MLA"},
+	"RUT":       {"MLC"},
+	"CURP":      {"MLM"},
+	"RFC":       {"MLM"},
+	"CC":        {"MCO"},
+	"CE":        {"MCO", "MPE"},
+	"NIT":       {"MCO"},
+	"RUC":       {"MPE"},
+	"CI":        {"MLU"},
+	"Otro":      {"MLA", "MLC", "MLM", "MCO", "MPE", "MLU"},
+	"Pasaporte": {"MCO", "MPE"},
+}
+
+// ValidIdentification reports whether the document type is used in the site
+// and its number is well formed. Check digits are verified for CPF, CNPJ,
+// CUIT, CUIL, RUT and CI. An empty siteID accepts every site.
+func ValidIdentification(siteID string, id Identification) bool {
+	sites, ok := _identificationSites[id.Type]
+	if !ok {
+		return false
+	}
+	if siteID != "" && !containsString(sites, siteID) {
+		return false
+	}
+
+	number := strings.ToUpper(strings.TrimSpace(id.Number))
+	digits := onlyDigits(number)
+
+	switch id.Type {
+	case "CPF":
+		return isFormatted(number, "0123456789.-") && validCPF(digits)
+	case "CNPJ":
+		return isFormatted(number, "0123456789./-") && validCNPJ(digits)
+	case "DNI":
+		return isFormatted(number, "0123456789.") && (len(digits) == 7 || len(digits) == 8)
+	case "CUIT", "CUIL":
+		return isFormatted(number, "0123456789-") && validCUIT(digits)
+	case "RUT":
+		return validRUT(number)
+	case "CURP":
+		return _curpRegexp.MatchString(number)
+	case "RFC":
+		return _rfcRegexp.MatchString(number)
+	case "CI":
+		return isFormatted(number, "0123456789.-") && validCI(digits)
+	case "CC", "NIT", "RUC", "LC", "LE":
+		return isFormatted(number, "0123456789.-") && len(digits) >= 5 && len(digits) <= 11
+	}
+
+	return number != ""
+}
+
+// validateIdentification is the "identification" validation of _v. Bodies
+// carry no site, so only the rules of the document type are checked here;
+// Handler.SiteID adds the site check to CreatePreference.
+func validateIdentification(fl validator.FieldLevel) bool {
+	id, ok := fl.Field().Interface().(Identification)
+	if !ok {
+		return false
+	}
+	if id == (Identification{}) {
+		return true
+	}
+	return ValidIdentification("", id)
+}
+
+func validCPF(d string) bool {
+	if len(d) != 11 || allSame(d) {
+		return false
+	}
+	return checkDigit(d[:9], 10) == int(d[9]-'0') && checkDigit(d[:10], 11) == int(d[10]-'0')
+}
+
+// checkDigit computes a CPF check digit, weighting digits from weight down
+// to 2.
+func checkDigit(d string, weight int) int {
+	sum := 0
+	for i := range d {
+		sum += int(d[i]-'0') * (weight - i)
+	}
+	r := sum % 11
+	if r < 2 {
+		return 0
+	}
+	return 11 - r
+}
+
+func validCNPJ(d string) bool {
+	if len(d) != 14 || allSame(d) {
+		return false
+	}
+	w1 := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	w2 := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	return mod11(d[:12], w1) == int(d[12]-'0') && mod11(d[:13], w2) == int(d[13]-'0')
+}
+
+func mod11(d string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(d[i]-'0') * w
+	}
+	r := sum % 11
+	if r < 2 {
+		return 0
+	}
+	return 11 - r
+}
+
+func validCUIT(d string) bool {
+	if len(d) != 11 {
+		return false
+	}
+	weights := []int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += int(d[i]-'0') * w
+	}
+	check := 11 - sum%11
+	switch check {
+	case 11:
+		check = 0
+	case 10:
+		check = 9
+	}
+	return check == int(d[10]-'0')
+}
+
+// validRUT checks a Chilean RUT such as 12.345.678-5, whose verifier may be K.
+func validRUT(number string) bool {
+	number = strings.NewReplacer(".", "", "-", "").Replace(number)
+	if len(number) < 2 {
+		return false
+	}
+	body, verifier := number[:len(number)-1], number[len(number)-1]
+	if onlyDigits(body) != body {
+		return false
+	}
+
+	sum, w := 0, 2
+	for i := len(body) - 1; i >= 0; i-- {
+		sum += int(body[i]-'0') * w
+		w++
+		if w > 7 {
+			w = 2
+		}
+	}
+
+	var expected byte
+	switch r := 11 - sum%11; r {
+	case 11:
+		expected = '0'
+	case 10:
+		expected = 'K'
+	default:
+		expected = byte('0' + r)
+	}
+	return verifier == expected
+}
+
+// validCI checks an Uruguayan CI, whose last digit is a check digit.
+func validCI(d string) bool {
+	if len(d) != 7 && len(d) != 8 {
+		return false
+	}
+	d = strings.Repeat("0", 8-len(d)) + d
+	weights := []int{2, 9, 8, 7, 6, 3, 4}
+	sum := 0
+	for i, w := range weights {
+		sum += int(d[i]-'0') * w
+	}
+	return (10-sum%10)%10 == int(d[7]-'0')
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isFormatted reports whether s only has characters in allowed.
+func isFormatted(s string, allowed string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune(allowed, r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func allSame(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_GetIdentificationTypes(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id": "CPF", "name": "CPF", "type": "number", "min_length": 11, "max_length": 11}, {"id": "CNPJ", "name": "CNPJ", "type": "number", "min_length": 14, "max_length": 14}]`))),
+    }
+    // When
+    types, err := g.GetIdentificationTypes("MY_ACCESS_TOKEN")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, []IdentificationType{
+        {Id: "CPF", Name: "CPF", Type: "number", Min_length: 11, Max_length: 11},
+        {Id: "CNPJ", Name: "CNPJ", Type: "number", Min_length: 14, Max_length: 14},
+    }, types)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/v1/identification_types", c.req.URL.Path)
+}
+
+func TestValidIdentification(t *testing.T) {
+    tt := []struct{
+        name string
+        siteID string
+        id Identification
+        want bool
+    }{
+        {name: "CPF", siteID: "MLB", id: Identification{Type: "CPF", Number: "12345678909"}, want: true},
+        {name: "formatted CPF", siteID: "MLB", id: Identification{Type: "CPF", Number: "529.982.247-25"}, want: true},
+        {name: "CPF with wrong check digits", siteID: "MLB", id: Identification{Type: "CPF", Number: "12345678900"}, want: false},
+        {name: "CPF with repeated digits", siteID: "MLB", id: Identification{Type: "CPF", Number: "11111111111"}, want: false},
+        {name: "lower case type", siteID: "MLB", id: Identification{Type: "cpf", Number: "12345678909"}, want: false},
+        {name: "CPF outside Brazil", siteID: "MLA", id: Identification{Type: "CPF", Number: "12345678909"}, want: false},
+        {name: "CNPJ", siteID: "MLB", id: Identification{Type: "CNPJ", Number: "11.222.333/0001-81"}, want: true},
+        {name: "CNPJ with wrong check digits", siteID: "MLB", id: Identification{Type: "CNPJ", Number: "11222333000182"}, want: false},
+        {name: "DNI", siteID: "MLA", id: Identification{Type: "DNI", Number: "12.345.678"}, want: true},
+        {name: "DNI too long", siteID: "MLA", id: Identification{Type: "DNI", Number: "123456789"}, want: false},
+        {name: "CUIT", siteID: "MLA", id: Identification{Type: "CUIT", Number: "20-12345678-6"}, want: true},
+        {name: "CUIT with wrong check digit", siteID: "MLA", id: Identification{Type: "CUIT", Number: "20-12345678-5"}, want: false},
+        {name: "RUT", siteID: "MLC", id: Identification{Type: "RUT", Number: "12.345.678-5"}, want: true},
+        {name: "RUT with K verifier", siteID: "MLC", id: Identification{Type: "RUT", Number: "10000013-k"}, want: true},
+        {name: "RUT with wrong verifier", siteID: "MLC", id: Identification{Type: "RUT", Number: "12.345.678-9"}, want: false},
+        {name: "CURP", siteID: "MLM", id: Identification{Type: "CURP", Number: "GODE561231HDFRRN09"}, want: true},
+        {name: "malformed CURP", siteID: "MLM", id: Identification{Type: "CURP", Number: "GODE561231"}, want: false},
+        {name: "CI", siteID: "MLU", id: Identification{Type: "CI", Number: "1.234.567-2"}, want: true},
+        {name: "CI with wrong check digit", siteID: "MLU", id: Identification{Type: "CI", Number: "1.234.567-3"}, want: false},
+        {name: "any site", id: Identification{Type: "DNI", Number: "12345678"}, want: true},
+        {name: "unknown type", id: Identification{Type: "SSN", Number: "123456789"}, want: false},
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            require.Equal(t, tc.want, ValidIdentification(tc.siteID, tc.id))
+        })
+    }
+}
+
+func TestValidator_Identification(t *testing.T) {
+    type payer struct {
+        Identification Identification `validate:"identification"`
+    }
+
+    require.NoError(t, _v.Struct(payer{Identification: Identification{Type: "CPF", Number: "12345678909"}}))
+
+    err := _v.Struct(payer{Identification: Identification{Type: "cpf", Number: "12345678909"}})
+    require.Error(t, err)
+    require.Contains(t, err.Error(), "'identification' tag")
+}
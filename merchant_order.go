@@ -0,0 +1,198 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Merchant order statuses.
+const (
+	MerchantOrderStatusOpened  = "opened"
+	MerchantOrderStatusClosed  = "closed"
+	MerchantOrderStatusExpired = "expired"
+)
+
+// Merchant order payment statuses, summarizing the payments of an order.
+const (
+	OrderStatusPaymentRequired    = "payment_required"
+	OrderStatusPaymentInProcess   = "payment_in_process"
+	OrderStatusReverted           = "reverted"
+	OrderStatusPaid               = "paid"
+	OrderStatusPartiallyReverted  = "partially_reverted"
+	OrderStatusPartiallyPaid      = "partially_paid"
+	OrderStatusPartiallyInProcess = "partially_in_process"
+	OrderStatusUndefined          = "undefined"
+	OrderStatusExpired            = "expired"
+)
+
+// MerchantOrder is the merchant order resource returned by
+// /merchant_orders. It gathers the payments and shipments of a preference.
+type MerchantOrder struct {
+	Id                 int64                    `json:"id"`
+	Status             string                   `json:"status"`
+	Order_status       string                   `json:"order_status"`
+	External_reference string                   `json:"external_reference"`
+	Preference_id      string                   `json:"preference_id"`
+	Payments           []MerchantOrderPayment   `json:"payments"`
+	Shipments          []MerchantOrderShipment  `json:"shipments"`
+	Collector          MerchantOrderParticipant `json:"collector"`
+	Payer              MerchantOrderParticipant `json:"payer"`
+	Marketplace        string                   `json:"marketplace"`
+	Notification_url   string                   `json:"notification_url"`
+	Additional_info    string                   `json:"additional_info"`
+	Application_id     string                   `json:"application_id"`
+	Site_id            string                   `json:"site_id"`
+	Sponsor_id         int64                    `json:"sponsor_id"`
+	Items              []PaymentItem            `json:"items"`
+	Total_amount       float64                  `json:"total_amount"`
+	Paid_amount        float64                  `json:"paid_amount"`
+	Refunded_amount    float64                  `json:"refunded_amount"`
+	Shipping_cost      float64                  `json:"shipping_cost"`
+	Cancelled          bool                     `json:"cancelled"`
+	Date_created       string                   `json:"date_created"`
+	Last_updated       string                   `json:"last_updated"`
+}
+
+type MerchantOrderParticipant struct {
+	Id       int64  `json:"id"`
+	Email    string `json:"email"`
+	Nickname string `json:"nickname"`
+}
+
+// MerchantOrderPayment summarizes one of the payments of a merchant order.
+type MerchantOrderPayment struct {
+	Id                 int64   `json:"id"`
+	Transaction_amount float64 `json:"transaction_amount"`
+	Total_paid_amount  float64 `json:"total_paid_amount"`
+	Shipping_cost      float64 `json:"shipping_cost"`
+	Currency_id        string  `json:"currency_id"`
+	Status             string  `json:"status"`
+	Status_detail      string  `json:"status_detail"`
+	Operation_type     string  `json:"operation_type"`
+	Date_approved      string  `json:"date_approved"`
+	Date_created       string  `json:"date_created"`
+	Last_modified      string  `json:"last_modified"`
+	Amount_refunded    float64 `json:"amount_refunded"`
+}
+
+type MerchantOrderShipment struct {
+	Id               int64                  `json:"id"`
+	Shipment_type    string                 `json:"shipment_type"`
+	Shipping_mode    string                 `json:"shipping_mode"`
+	Status           string                 `json:"status"`
+	Substatus        string                 `json:"substatus"`
+	Date_created     string                 `json:"date_created"`
+	Last_modified    string                 `json:"last_modified"`
+	Receiver_address map[string]interface{} `json:"receiver_address"`
+	Shipping_option  map[string]interface{} `json:"shipping_option"`
+}
+
+// MerchantOrders is a page of merchant orders returned by
+// /merchant_orders/search.
+type MerchantOrders struct {
+	Elements    []MerchantOrder `json:"elements"`
+	Next_offset int             `json:"next_offset"`
+	Total       int             `json:"total"`
+}
+
+// MerchantOrderSearch filters a merchant orders search. Empty fields are left
+// out of the query.
+type MerchantOrderSearch struct {
+	Preference_id      string
+	External_reference string
+	Status             string
+	Limit              int
+	Offset             int
+}
+
+func (s MerchantOrderSearch) query() string {
+	q := url.Values{}
+	if s.Preference_id != "" {
+		q.Set("preference_id", s.Preference_id)
+	}
+	if s.External_reference != "" {
+		q.Set("external_reference", s.External_reference)
+	}
+	if s.Status != "" {
+		q.Set("status", s.Status)
+	}
+	if s.Limit > 0 {
+		q.Set("limit", strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		q.Set("offset", strconv.Itoa(s.Offset))
+	}
+	return q.Encode()
+}
+
+// NewMerchantOrder is the body of CreateMerchantOrder.
+type NewMerchantOrder struct {
+	Preference_id      string                    `json:"preference_id,omitempty"`
+	External_reference string                    `json:"external_reference,omitempty"`
+	Notification_url   string                    `json:"notification_url,omitempty"`
+	Additional_info    string                    `json:"additional_info,omitempty"`
+	Site_id            string                    `json:"site_id,omitempty"`
+	Sponsor_id         int64                     `json:"sponsor_id,omitempty"`
+	Marketplace        string                    `json:"marketplace,omitempty"`
+	Application_id     string                    `json:"application_id,omitempty"`
+	Payer              *MerchantOrderParticipant `json:"payer,omitempty"`
+	Items              []PaymentItem             `json:"items,omitempty"`
+}
+
+// MerchantOrderUpdate is the body of UpdateMerchantOrder; only the fields set
+// are changed.
+type MerchantOrderUpdate struct {
+	External_reference string        `json:"external_reference,omitempty"`
+	Notification_url   string        `json:"notification_url,omitempty"`
+	Additional_info    string        `json:"additional_info,omitempty"`
+	Sponsor_id         int64         `json:"sponsor_id,omitempty"`
+	Items              []PaymentItem `json:"items,omitempty"`
+	Cancelled          *bool         `json:"cancelled,omitempty"`
+}
+
+func (g *Gateway) GetMerchantOrder(accessToken string, id string) (MerchantOrder, error) {
+	return g.GetMerchantOrderWithContext(context.Background(), accessToken, id)
+}
+
+func (g *Gateway) GetMerchantOrderWithContext(ctx context.Context, accessToken string, id string) (order MerchantOrder, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/merchant_orders/"+id, accessToken, nil, &order)
+	return
+}
+
+func (g *Gateway) SearchMerchantOrders(accessToken string, search MerchantOrderSearch) (MerchantOrders, error) {
+	return g.SearchMerchantOrdersWithContext(context.Background(), accessToken, search)
+}
+
+func (g *Gateway) SearchMerchantOrdersWithContext(ctx context.Context, accessToken string, search MerchantOrderSearch) (orders MerchantOrders, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/merchant_orders/search?"+search.query(), accessToken, nil, &orders)
+	return
+}
+
+// GetMerchantOrders returns the merchant orders of a preference.
+func (g *Gateway) GetMerchantOrders(accessToken string, preferenceID string) (MerchantOrders, error) {
+	return g.GetMerchantOrdersWithContext(context.Background(), accessToken, preferenceID)
+}
+
+func (g *Gateway) GetMerchantOrdersWithContext(ctx context.Context, accessToken string, preferenceID string) (MerchantOrders, error) {
+	return g.SearchMerchantOrdersWithContext(ctx, accessToken, MerchantOrderSearch{Preference_id: preferenceID})
+}
+
+func (g *Gateway) CreateMerchantOrder(accessToken string, order NewMerchantOrder) (MerchantOrder, error) {
+	return g.CreateMerchantOrderWithContext(context.Background(), accessToken, order)
+}
+
+func (g *Gateway) CreateMerchantOrderWithContext(ctx context.Context, accessToken string, order NewMerchantOrder) (created MerchantOrder, err error) {
+	err = g.doJSON(ctx, http.MethodPost, "/merchant_orders", accessToken, order, &created)
+	return
+}
+
+func (g *Gateway) UpdateMerchantOrder(accessToken string, id string, update MerchantOrderUpdate) (MerchantOrder, error) {
+	return g.UpdateMerchantOrderWithContext(context.Background(), accessToken, id, update)
+}
+
+func (g *Gateway) UpdateMerchantOrderWithContext(ctx context.Context, accessToken string, id string, update MerchantOrderUpdate) (updated MerchantOrder, err error) {
+	err = g.doJSON(ctx, http.MethodPut, "/merchant_orders/"+id, accessToken, update, &updated)
+	return
+}
@@ -0,0 +1,155 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_GetMerchantOrder(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 1234567890, "status": "closed", "order_status": "paid", "preference_id": "PREF_ID", "external_reference": "ORDER_1", "collector": {"id": 98765, "email": "seller@example.com"}, "payments": [{"id": 111, "transaction_amount": 100.5, "status": "approved"}], "shipments": [{"id": 222, "status": "ready_to_ship"}], "total_amount": 100.5, "paid_amount": 100.5}`))),
+    }
+    // When
+    order, err := g.GetMerchantOrder("MY_ACCESS_TOKEN", "1234567890")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, int64(1234567890), order.Id)
+    require.Equal(t, MerchantOrderStatusClosed, order.Status)
+    require.Equal(t, OrderStatusPaid, order.Order_status)
+    require.Equal(t, int64(98765), order.Collector.Id)
+    require.Len(t, order.Payments, 1)
+    require.Equal(t, int64(111), order.Payments[0].Id)
+    require.Equal(t, "approved", order.Payments[0].Status)
+    require.Len(t, order.Shipments, 1)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/merchant_orders/1234567890", c.req.URL.Path)
+    require.Equal(t, "Bearer MY_ACCESS_TOKEN", c.req.Header.Get("Authorization"))
+}
+
+func TestGateway_SearchMerchantOrders(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"elements": [{"id": 1, "status": "opened"}, {"id": 2, "status": "opened"}], "next_offset": 12, "total": 30}`))),
+    }
+    // When
+    orders, err := g.SearchMerchantOrders("MY_ACCESS_TOKEN", MerchantOrderSearch{
+        External_reference: "ORDER 1/2",
+        Status:             MerchantOrderStatusOpened,
+        Limit:              10,
+        Offset:             2,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Len(t, orders.Elements, 2)
+    require.Equal(t, 12, orders.Next_offset)
+    require.Equal(t, 30, orders.Total)
+    require.Equal(t, "/merchant_orders/search", c.req.URL.Path)
+    require.Equal(t, "ORDER 1/2", c.req.URL.Query().Get("external_reference"))
+    require.Equal(t, "opened", c.req.URL.Query().Get("status"))
+    require.Equal(t, "10", c.req.URL.Query().Get("limit"))
+    require.Equal(t, "2", c.req.URL.Query().Get("offset"))
+    require.Empty(t, c.req.URL.Query().Get("preference_id"))
+}
+
+func TestGateway_GetMerchantOrders(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"elements": [{"id": 1, "preference_id": "PREF_ID"}], "total": 1}`))),
+    }
+    // When
+    orders, err := g.GetMerchantOrders("MY_ACCESS_TOKEN", "PREF_ID")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "PREF_ID", orders.Elements[0].Preference_id)
+    require.Equal(t, "preference_id=PREF_ID", c.req.URL.RawQuery)
+}
+
+func TestGateway_CreateMerchantOrder(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 1, "status": "opened", "preference_id": "PREF_ID"}`))),
+    }
+    // When
+    order, err := g.CreateMerchantOrder("MY_ACCESS_TOKEN", NewMerchantOrder{
+        Preference_id:      "PREF_ID",
+        External_reference: "ORDER_1",
+        Items: []PaymentItem{
+            {Title: "Item", Quantity: 1, Unit_price: 10},
+        },
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, int64(1), order.Id)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/merchant_orders", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"preference_id": "PREF_ID", "external_reference": "ORDER_1", "items": [{"title": "Item", "quantity": 1, "unit_price": 10}]}`, string(b))
+}
+
+func TestGateway_UpdateMerchantOrder(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 1, "cancelled": true}`))),
+    }
+    cancelled := true
+    // When
+    order, err := g.UpdateMerchantOrder("MY_ACCESS_TOKEN", "1", MerchantOrderUpdate{Cancelled: &cancelled})
+
+    // Then
+    require.NoError(t, err)
+    require.True(t, order.Cancelled)
+    require.Equal(t, http.MethodPut, c.req.Method)
+    require.Equal(t, "/merchant_orders/1", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"cancelled": true}`, string(b))
+}
+
+func TestGateway_GetMerchantOrder_MercadoPagoError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "404",
+        StatusCode: 404,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message": "Merchant Order not found", "error": "not_found", "status": 404}`))),
+    }
+    // When
+    _, err := g.GetMerchantOrder("MY_ACCESS_TOKEN", "1")
+
+    // Then
+    require.Error(t, err)
+    require.True(t, IsNotFound(err))
+    require.Equal(t, "Merchant Order not found", err.Error())
+}
@@ -1,60 +1,146 @@
 package mercadopago
 
+import (
+    "encoding/json"
+    "reflect"
+)
+
 type Credentials struct {
     ClientID string
     ClientSecret string
 }
 
 type Item struct {
-    Id       	string  `json:"id" validate:"id"`
+    Id          string  `json:"id,omitempty"`
     Title       string  `json:"title" validate:"required"`
-    Description string  `json:"description"`
-    PictureURL  string  `json:"picture_url"`
-    Category_id string  `json:"category_id"`
-    Currency_id string  `json:"currency_id"`
+    Description string  `json:"description,omitempty"`
+    PictureURL  string  `json:"picture_url,omitempty"`
+    Category_id string  `json:"category_id,omitempty"`
+    Currency_id string  `json:"currency_id,omitempty"`
     Quantity    int     `json:"quantity" validate:"required"`
     UnitPrice   float64 `json:"unit_price" validate:"required"`
 }
 
 type Payer struct {
-    First_name    	string `json:"first_name" validate:"first_name"`
-    Last_name    	string `json:"last_name" validate:"last_name"`
-    Email   		string `json:"email" validate:"required"`
-    Phone 			Phone `json:"phone" validate:"required"`
-    Identification 	Identification `json:"identification" validate:"identification"`
-    Address 		Address `json:"address" validate:"required"`
-    CreatedAt 		string `json:"date_created" validate:"required"`
+    // Id is the ID of the customer paying, so Checkout offers its saved cards.
+    Id             string         `json:"id,omitempty"`
+    First_name     string         `json:"first_name,omitempty"`
+    Last_name      string         `json:"last_name,omitempty"`
+    Email          string         `json:"email,omitempty" validate:"required"`
+    Phone          Phone          `json:"phone,omitempty" validate:"required"`
+    Identification Identification `json:"identification,omitempty" validate:"identification"`
+    Address        Address        `json:"address,omitempty" validate:"required"`
+    CreatedAt      string         `json:"date_created,omitempty" validate:"required"`
+}
+
+// MarshalJSON leaves Phone, Identification and Address out when they are
+// unset, which omitempty does not do for structs.
+func (p Payer) MarshalJSON() ([]byte, error) {
+    type payer Payer
+    out := struct {
+        payer
+        Phone          *Phone          `json:"phone,omitempty"`
+        Identification *Identification `json:"identification,omitempty"`
+        Address        *Address        `json:"address,omitempty"`
+    }{payer: payer(p)}
+    if p.Phone != (Phone{}) {
+        out.Phone = &p.Phone
+    }
+    if p.Identification != (Identification{}) {
+        out.Identification = &p.Identification
+    }
+    if p.Address != (Address{}) {
+        out.Address = &p.Address
+    }
+    return json.Marshal(out)
 }
 
 type Phone struct {
-    Area_code 	string `json:"area_code"`
-    Number   	string `json:"number" validate:"required"`
+    Area_code string `json:"area_code,omitempty"`
+    Number    string `json:"number,omitempty" validate:"required"`
 }
 
 type Identification struct {
-    Type 	string `json:"type"`
-    Number  string `json:"number" validate:"required"`
+    Type    string `json:"type,omitempty"`
+    Number  string `json:"number,omitempty"`
 }
 
 type Address struct {
-    Zip_code 		string `json:"zip_code"`
-    Street_name  	string `json:"street_name" validate:"street_name"`
-    Street_number  	int    `json:"street_number" validate:"street_number"`
-    Neighborhood  	string `json:"neighborhood" validate:"neighborhood"`
-    City  			string `json:"city" validate:"city"`
+    Zip_code      string `json:"zip_code,omitempty"`
+    Street_name   string `json:"street_name,omitempty"`
+    Street_number int    `json:"street_number,omitempty"`
+    Neighborhood  string `json:"neighborhood,omitempty"`
+    City          string `json:"city,omitempty"`
 }
 
+// Auto return values of a preference: back to Back_urls after approved
+// payments only, or after every payment.
+const (
+    AutoReturnApproved = "approved"
+    AutoReturnAll      = "all"
+)
+
+// Preference purposes.
+const (
+    PurposeWalletPurchase    = "wallet_purchase"
+    PurposeOnboardingCredits = "onboarding_credits"
+)
+
+// Processing modes of a preference.
+const (
+    ProcessingModeAggregator = "aggregator"
+    ProcessingModeGateway    = "gateway"
+)
+
+// NewPreference is the body of CreatePreference. Fields left unset are not
+// sent, so the API defaults apply.
 type NewPreference struct {
-	External_reference 	string `json:"external_reference"`
-	Description 		string `json:"description"`
-    Items 				[]Item `json:"items" validate:"required,min=1"`
-	Payment_method_id	string `json:"payment_method_id"`
-    Payment_methods 	Payment_methods `json:"payment_methods"`
-    Notification_url 	string `json:"notification_url"`
-    Payer 				Payer `json:"payer" validate:"required"`
-    //Redirect_urls 		Redirect_urls `json:"redirect_urls"`
-    Back_urls 			Back_urls `json:"back_urls"`
-    AutoReturn 			string `json:"auto_return"`
+    External_reference   string                 `json:"external_reference,omitempty"`
+    Description          string                 `json:"description,omitempty"`
+    Items                []Item                 `json:"items" validate:"required,min=1"`
+    Payment_method_id    string                 `json:"payment_method_id,omitempty"`
+    Payment_methods      Payment_methods        `json:"payment_methods,omitempty"`
+    Notification_url     string                 `json:"notification_url,omitempty"`
+    Payer                Payer                  `json:"payer" validate:"required"`
+    //Redirect_urls      Redirect_urls          `json:"redirect_urls"`
+    Back_urls            Back_urls              `json:"back_urls,omitempty"`
+    AutoReturn           string                 `json:"auto_return,omitempty"`
+    Shipments            *Shipments             `json:"shipments,omitempty"`
+    // Expires limits the preference to the period between
+    // Expiration_date_from and Expiration_date_to, formatted as
+    // "2006-01-02T15:04:05.000-07:00".
+    Expires              bool                   `json:"expires,omitempty"`
+    Expiration_date_from string                 `json:"expiration_date_from,omitempty"`
+    Expiration_date_to   string                 `json:"expiration_date_to,omitempty"`
+    // Binary_mode makes payments either approved or rejected, never pending.
+    Binary_mode          bool                   `json:"binary_mode,omitempty"`
+    Statement_descriptor string                 `json:"statement_descriptor,omitempty"`
+    Additional_info      string                 `json:"additional_info,omitempty"`
+    Metadata             map[string]interface{} `json:"metadata,omitempty"`
+    Marketplace          string                 `json:"marketplace,omitempty"`
+    Marketplace_fee      float64                `json:"marketplace_fee,omitempty"`
+    Differential_pricing *Differential_pricing  `json:"differential_pricing,omitempty"`
+    Tracks               []Track                `json:"tracks,omitempty"`
+    Purpose              string                 `json:"purpose,omitempty"`
+    Processing_modes     []string               `json:"processing_modes,omitempty"`
+}
+
+// MarshalJSON leaves Payment_methods and Back_urls out when they are unset,
+// which omitempty does not do for structs.
+func (p NewPreference) MarshalJSON() ([]byte, error) {
+    type newPreference NewPreference
+    out := struct {
+        newPreference
+        Payment_methods *Payment_methods `json:"payment_methods,omitempty"`
+        Back_urls       *Back_urls       `json:"back_urls,omitempty"`
+    }{newPreference: newPreference(p)}
+    if !reflect.ValueOf(p.Payment_methods).IsZero() {
+        out.Payment_methods = &p.Payment_methods
+    }
+    if p.Back_urls != (Back_urls{}) {
+        out.Back_urls = &p.Back_urls
+    }
+    return json.Marshal(out)
 }
 
 /*type Redirect_urls struct {
@@ -64,17 +150,78 @@ type NewPreference struct {
 }*/
 
 type Back_urls struct {
-    Success string `json:"success"`
-    Pending string `json:"pending"`
-    Failure string `json:"failure"`
+    Success string `json:"success,omitempty"`
+    Pending string `json:"pending,omitempty"`
+    Failure string `json:"failure,omitempty"`
 }
 
 type Payment_methods struct {
-	Excluded_payment_methods 	[]Excluded_payment_methods `json:"excluded_payment_methods"`
-    Installments 				int `json:"installments"`
-    //Default_installments		int `json:"default_installments"`
+    Excluded_payment_methods  []Excluded_payment_methods `json:"excluded_payment_methods,omitempty"`
+    Excluded_payment_types    []Excluded_payment_types   `json:"excluded_payment_types,omitempty"`
+    Default_payment_method_id string                     `json:"default_payment_method_id,omitempty"`
+    // Installments is the maximum number of installments offered.
+    Installments              int                        `json:"installments,omitempty"`
+    Default_installments      int                        `json:"default_installments,omitempty"`
 }
 
 type Excluded_payment_methods struct {
-	Id	string `json:"id"`
+    Id string `json:"id"`
+}
+
+// Excluded_payment_types excludes a payment type, e.g. "ticket" or
+// "credit_card", from Checkout.
+type Excluded_payment_types struct {
+    Id string `json:"id"`
+}
+
+// Shipping modes of a preference.
+const (
+    ShippingModeCustom       = "custom"
+    ShippingModeME2          = "me2"
+    ShippingModeNotSpecified = "not_specified"
+)
+
+type Shipments struct {
+    Mode                    string           `json:"mode,omitempty"`
+    Local_pickup            bool             `json:"local_pickup,omitempty"`
+    // Dimensions are the package dimensions and weight, as
+    // "<height>x<width>x<length>,<weight>" in centimeters and grams.
+    Dimensions              string           `json:"dimensions,omitempty"`
+    Default_shipping_method int64            `json:"default_shipping_method,omitempty"`
+    Free_methods            []FreeMethod     `json:"free_methods,omitempty"`
+    Cost                    float64          `json:"cost,omitempty"`
+    Free_shipping           bool             `json:"free_shipping,omitempty"`
+    Receiver_address        *ReceiverAddress `json:"receiver_address,omitempty"`
+}
+
+type FreeMethod struct {
+    Id int64 `json:"id"`
+}
+
+type ReceiverAddress struct {
+    Zip_code      string `json:"zip_code,omitempty"`
+    Street_name   string `json:"street_name,omitempty"`
+    Street_number int    `json:"street_number,omitempty"`
+    Floor         string `json:"floor,omitempty"`
+    Apartment     string `json:"apartment,omitempty"`
+    City_name     string `json:"city_name,omitempty"`
+    State_name    string `json:"state_name,omitempty"`
+    Country_name  string `json:"country_name,omitempty"`
+}
+
+type Differential_pricing struct {
+    Id int64 `json:"id"`
+}
+
+// Track types of a preference.
+const (
+    TrackTypeGoogleAd   = "google_ad"
+    TrackTypeFacebookAd = "facebook_ad"
+)
+
+// Track is an ad conversion tracker fired by Checkout, e.g. a Facebook pixel
+// with Values {"pixel_id": "..."}.
+type Track struct {
+    Type   string                 `json:"type"`
+    Values map[string]interface{} `json:"values"`
 }
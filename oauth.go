@@ -0,0 +1,132 @@
+package mercadopago
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const _authURL = "https://auth.mercadopago.com/authorization"
+
+// PKCE holds a proof key for the authorization code flow (RFC 7636). The
+// challenge goes in the authorization URL and the verifier is sent when the
+// code is exchanged.
+type PKCE struct {
+	Verifier        string
+	Challenge       string
+	ChallengeMethod string
+}
+
+// NewPKCE generates a random verifier and its S256 challenge.
+func NewPKCE() (PKCE, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return PKCE{}, err
+	}
+
+	return newPKCE(base64.RawURLEncoding.EncodeToString(b)), nil
+}
+
+func newPKCE(verifier string) PKCE {
+	sum := sha256.Sum256([]byte(verifier))
+	return PKCE{
+		Verifier:        verifier,
+		Challenge:       base64.RawURLEncoding.EncodeToString(sum[:]),
+		ChallengeMethod: "S256",
+	}
+}
+
+// AuthorizationURL returns the page where a seller links their MercadoPago
+// account to the application. MercadoPago redirects back to redirectURI with
+// the authorization code and state. pkce may be nil.
+func (g *Gateway) AuthorizationURL(clientID string, redirectURI string, state string, pkce *PKCE) string {
+	queryValues := &url.Values{}
+	queryValues.Add("client_id", clientID)
+	queryValues.Add("response_type", "code")
+	queryValues.Add("platform_id", "mp")
+	queryValues.Add("redirect_uri", redirectURI)
+	if state != "" {
+		queryValues.Add("state", state)
+	}
+	if pkce != nil {
+		queryValues.Add("code_challenge", pkce.Challenge)
+		queryValues.Add("code_challenge_method", pkce.ChallengeMethod)
+	}
+
+	authURL := g.authURL
+	if authURL == "" {
+		authURL = _authURL
+	}
+
+	return authURL + "?" + queryValues.Encode()
+}
+
+// ExchangeCode trades the authorization code received on redirectURI for the
+// seller's tokens. codeVerifier is the PKCE verifier, if one was used.
+func (g *Gateway) ExchangeCode(credentials Credentials, code string, redirectURI string, codeVerifier string) (Token, error) {
+	return g.ExchangeCodeWithContext(context.Background(), credentials, code, redirectURI, codeVerifier)
+}
+
+func (g *Gateway) ExchangeCodeWithContext(ctx context.Context, credentials Credentials, code string, redirectURI string, codeVerifier string) (Token, error) {
+	return g.requestToken(ctx, tokenRequest{
+		ClientID:     credentials.ClientID,
+		ClientSecret: credentials.ClientSecret,
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  redirectURI,
+		CodeVerifier: codeVerifier,
+	})
+}
+
+// RefreshToken renews a seller's tokens; the returned token carries a new
+// refresh token that replaces the previous one.
+func (g *Gateway) RefreshToken(credentials Credentials, refreshToken string) (Token, error) {
+	return g.RefreshTokenWithContext(context.Background(), credentials, refreshToken)
+}
+
+func (g *Gateway) RefreshTokenWithContext(ctx context.Context, credentials Credentials, refreshToken string) (Token, error) {
+	return g.requestToken(ctx, tokenRequest{
+		ClientID:     credentials.ClientID,
+		ClientSecret: credentials.ClientSecret,
+		GrantType:    "refresh_token",
+		RefreshToken: refreshToken,
+	})
+}
+
+type tokenRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	GrantType    string `json:"grant_type"`
+	Code         string `json:"code,omitempty"`
+	RedirectURI  string `json:"redirect_uri,omitempty"`
+	CodeVerifier string `json:"code_verifier,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+func (g *Gateway) requestToken(ctx context.Context, body tokenRequest) (Token, error) {
+	req, err := g.newRequest(ctx, http.MethodPost, "/oauth/token", body)
+	if err != nil {
+		return Token{}, err
+	}
+
+	return g.sendTokenRequest(req)
+}
+
+// sendTokenRequest sends a request to /oauth/token and computes the expiry of
+// the returned token.
+func (g *Gateway) sendTokenRequest(req *http.Request) (Token, error) {
+	var token Token
+	if err := g.send(req, &token); err != nil {
+		return Token{}, err
+	}
+
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
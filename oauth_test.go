@@ -0,0 +1,92 @@
+package mercadopago
+
+import (
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "net/url"
+    "testing"
+)
+
+func TestNewPKCE(t *testing.T) {
+    // RFC 7636, appendix B.
+    pkce := newPKCE("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+    require.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", pkce.Challenge)
+    require.Equal(t, "S256", pkce.ChallengeMethod)
+
+    random, err := NewPKCE()
+    require.NoError(t, err)
+    require.Len(t, random.Verifier, 43)
+}
+
+func TestGateway_AuthorizationURL(t *testing.T) {
+    // Given
+    g := NewGateway(nil, WithAuthURL("https://auth.mercadopago.com.br/authorization"))
+    pkce := newPKCE("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+
+    // When
+    u, err := url.Parse(g.AuthorizationURL("APP_ID", "https://example.com/callback", "STATE", &pkce))
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "auth.mercadopago.com.br", u.Host)
+    require.Equal(t, "APP_ID", u.Query().Get("client_id"))
+    require.Equal(t, "code", u.Query().Get("response_type"))
+    require.Equal(t, "mp", u.Query().Get("platform_id"))
+    require.Equal(t, "https://example.com/callback", u.Query().Get("redirect_uri"))
+    require.Equal(t, "STATE", u.Query().Get("state"))
+    require.Equal(t, pkce.Challenge, u.Query().Get("code_challenge"))
+    require.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+}
+
+func TestGateway_ExchangeCode(t *testing.T) {
+    // Given
+    c := &ClientStub{resp: newResponse(http.StatusOK, `{"access_token": "APP_USR-SELLER", "token_type": "bearer", "expires_in": 15552000, "scope": "offline_access read write", "user_id": 12345, "refresh_token": "TG-SELLER", "public_key": "APP_USR-PUBLIC", "live_mode": true}`)}
+    g := &Gateway{Client: c}
+
+    // When
+    token, err := g.ExchangeCode(Credentials{ClientID: "APP_ID", ClientSecret: "SECRET"}, "TG-CODE", "https://example.com/callback", "VERIFIER")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "APP_USR-SELLER", token.AccessToken)
+    require.Equal(t, int64(12345), token.UserID)
+    require.Equal(t, "APP_USR-PUBLIC", token.PublicKey)
+    require.True(t, token.LiveMode)
+    require.False(t, token.Expiry.IsZero())
+
+    require.Equal(t, "/oauth/token", c.req.URL.Path)
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"client_id": "APP_ID", "client_secret": "SECRET", "grant_type": "authorization_code", "code": "TG-CODE", "redirect_uri": "https://example.com/callback", "code_verifier": "VERIFIER"}`, string(b))
+}
+
+func TestGateway_RefreshToken(t *testing.T) {
+    // Given
+    c := &ClientStub{resp: newResponse(http.StatusOK, `{"access_token": "APP_USR-NEW", "refresh_token": "TG-NEW", "expires_in": 15552000}`)}
+    g := &Gateway{Client: c}
+
+    // When
+    token, err := g.RefreshToken(Credentials{ClientID: "APP_ID", ClientSecret: "SECRET"}, "TG-OLD")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "TG-NEW", token.RefreshToken)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"client_id": "APP_ID", "client_secret": "SECRET", "grant_type": "refresh_token", "refresh_token": "TG-OLD"}`, string(b))
+}
+
+func TestGateway_ExchangeCode_MercadoPagoError(t *testing.T) {
+    // Given
+    c := &ClientStub{resp: newResponse(http.StatusBadRequest, `{"message": "invalid_grant", "error": "invalid_grant", "status": 400}`)}
+    g := &Gateway{Client: c}
+
+    // When
+    _, err := g.ExchangeCode(Credentials{ClientID: "APP_ID", ClientSecret: "SECRET"}, "TG-CODE", "https://example.com/callback", "")
+
+    // Then
+    require.EqualError(t, err, "invalid_grant")
+    require.True(t, IsValidation(err))
+}
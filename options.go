@@ -0,0 +1,91 @@
+package mercadopago
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GatewayOption configures a Gateway built with NewGateway.
+type GatewayOption func(*Gateway)
+
+// NewGateway returns a Gateway that sends its requests through client,
+// configured by opts. With no options it behaves like NewClientGateway.
+func NewGateway(client Client, opts ...GatewayOption) *Gateway {
+	g := NewClientGateway(client)
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithBaseURL points the gateway at baseURL instead of the MercadoPago API,
+// e.g. a local stand-in, an egress proxy or a fixture server.
+func WithBaseURL(baseURL string) GatewayOption {
+	return func(g *Gateway) {
+		g.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithAuthURL sets the authorization page sellers are sent to by
+// AuthorizationURL, e.g. the one of their country such as
+// https://auth.mercadopago.com.br/authorization.
+func WithAuthURL(authURL string) GatewayOption {
+	return func(g *Gateway) {
+		g.authURL = authURL
+	}
+}
+
+// WithHeader sends the given header on every request.
+func WithHeader(key string, value string) GatewayOption {
+	return func(g *Gateway) {
+		if g.headers == nil {
+			g.headers = http.Header{}
+		}
+		g.headers.Set(key, value)
+	}
+}
+
+// WithHeaders sends the given headers on every request.
+func WithHeaders(headers http.Header) GatewayOption {
+	return func(g *Gateway) {
+		if g.headers == nil {
+			g.headers = http.Header{}
+		}
+		for key, values := range headers {
+			for _, value := range values {
+				g.headers.Add(key, value)
+			}
+		}
+	}
+}
+
+func WithUserAgent(userAgent string) GatewayOption {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithPlatformID identifies the platform the integration runs on through the
+// x-platform-id header.
+func WithPlatformID(platformID string) GatewayOption {
+	return WithHeader("X-Platform-Id", platformID)
+}
+
+// WithIntegratorID identifies the developer or agency that built the
+// integration through the x-integrator-id header.
+func WithIntegratorID(integratorID string) GatewayOption {
+	return WithHeader("X-Integrator-Id", integratorID)
+}
+
+// WithCorporationID identifies the corporation the seller belongs to through
+// the x-corporation-id header.
+func WithCorporationID(corporationID string) GatewayOption {
+	return WithHeader("X-Corporation-Id", corporationID)
+}
+
+// WithTimeout bounds every call that doesn't already carry a context
+// deadline.
+func WithTimeout(timeout time.Duration) GatewayOption {
+	return func(g *Gateway) {
+		g.timeout = timeout
+	}
+}
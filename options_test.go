@@ -0,0 +1,64 @@
+package mercadopago
+
+import (
+    "context"
+    "errors"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestNewGateway_Options(t *testing.T) {
+    // Given
+    var got *http.Request
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        got = r
+        w.Write([]byte(`{"id": 123, "status": "approved"}`))
+    }))
+    defer ts.Close()
+
+    g := NewGateway(http.DefaultClient,
+        WithBaseURL(ts.URL+"/"),
+        WithUserAgent("my-shop/1.0"),
+        WithHeader("X-Custom", "custom"),
+        WithPlatformID("PLATFORM"),
+        WithIntegratorID("INTEGRATOR"),
+        WithCorporationID("CORPORATION"),
+    )
+
+    // When
+    payment, err := g.GetPayments("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 123, payment.Id)
+    require.Equal(t, "/v1/payments/123", got.URL.Path)
+    require.Equal(t, "my-shop/1.0", got.Header.Get("User-Agent"))
+    require.Equal(t, "custom", got.Header.Get("X-Custom"))
+    require.Equal(t, "PLATFORM", got.Header.Get("x-platform-id"))
+    require.Equal(t, "INTEGRATOR", got.Header.Get("x-integrator-id"))
+    require.Equal(t, "CORPORATION", got.Header.Get("x-corporation-id"))
+    require.Equal(t, "Bearer MY_ACCESS_TOKEN", got.Header.Get("Authorization"))
+}
+
+func TestNewGateway_WithTimeout(t *testing.T) {
+    // Given
+    ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        select {
+        case <-r.Context().Done():
+        case <-time.After(time.Second):
+        }
+    }))
+    defer ts.Close()
+
+    g := NewGateway(http.DefaultClient, WithBaseURL(ts.URL), WithTimeout(10*time.Millisecond))
+
+    // When
+    _, err := g.GetPayments("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.Error(t, err)
+    require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
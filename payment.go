@@ -0,0 +1,349 @@
+package mercadopago
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// _dateLayout is the date format MercadoPago expects, e.g.
+// 2022-11-17T09:37:52.000-04:00.
+const _dateLayout = "2006-01-02T15:04:05.000-07:00"
+
+// Payment statuses reported by MercadoPago in Payment.Status.
+const (
+	PaymentStatusPending     = "pending"
+	PaymentStatusApproved    = "approved"
+	PaymentStatusAuthorized  = "authorized"
+	PaymentStatusInProcess   = "in_process"
+	PaymentStatusInMediation = "in_mediation"
+	PaymentStatusRejected    = "rejected"
+	PaymentStatusCancelled   = "cancelled"
+	PaymentStatusRefunded    = "refunded"
+	PaymentStatusChargedBack = "charged_back"
+)
+
+// Status details of payments waiting for the payer, and of the ones that
+// expired unpaid.
+const (
+	StatusDetailPendingWaitingPayment  = "pending_waiting_payment"
+	StatusDetailPendingWaitingTransfer = "pending_waiting_transfer"
+	StatusDetailExpired                = "expired"
+)
+
+// NewPayment is the body sent to POST /v1/payments.
+type NewPayment struct {
+	Transaction_amount   float64                `json:"transaction_amount" validate:"required,gt=0"`
+	Token                string                 `json:"token,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Installments         int                    `json:"installments,omitempty"`
+	Payment_method_id    string                 `json:"payment_method_id" validate:"required"`
+	Issuer_id            string                 `json:"issuer_id,omitempty"`
+	Payer                PaymentPayer           `json:"payer"`
+	Additional_info      *PaymentAdditionalInfo `json:"additional_info,omitempty"`
+	Metadata             map[string]interface{} `json:"metadata,omitempty"`
+	Binary_mode          bool                   `json:"binary_mode,omitempty"`
+	Statement_descriptor string                 `json:"statement_descriptor,omitempty"`
+	External_reference   string                 `json:"external_reference,omitempty"`
+	Notification_url     string                 `json:"notification_url,omitempty"`
+	Date_of_expiration   string                 `json:"date_of_expiration,omitempty"`
+	// Capture set to false only authorizes a card payment; it must then be
+	// captured with CapturePayment or released with CancelPayment.
+	Capture *bool `json:"capture,omitempty"`
+}
+
+// PaymentUpdate is the body sent to PUT /v1/payments/{id}. Only the fields
+// that are set are sent.
+type PaymentUpdate struct {
+	Status             string                 `json:"status,omitempty"`
+	Capture            *bool                  `json:"capture,omitempty"`
+	Transaction_amount float64                `json:"transaction_amount,omitempty"`
+	Date_of_expiration string                 `json:"date_of_expiration,omitempty"`
+	Metadata           map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type PaymentPayer struct {
+	Id             string         `json:"id,omitempty"`
+	Type           string         `json:"type,omitempty"`
+	Entity_type    string         `json:"entity_type,omitempty"`
+	Email          string         `json:"email,omitempty" validate:"required"`
+	First_name     string         `json:"first_name,omitempty"`
+	Last_name      string         `json:"last_name,omitempty"`
+	Identification Identification `json:"identification"`
+	// Address is required by some offline payment methods, such as boleto.
+	Address *PaymentPayerAddress `json:"address,omitempty"`
+}
+
+// MarshalJSON leaves Identification out when it is unset, which omitempty
+// does not do for structs.
+func (p PaymentPayer) MarshalJSON() ([]byte, error) {
+	type payer PaymentPayer
+	out := struct {
+		payer
+		Identification *Identification `json:"identification,omitempty"`
+	}{payer: payer(p)}
+	if p.Identification != (Identification{}) {
+		out.Identification = &p.Identification
+	}
+	return json.Marshal(out)
+}
+
+type PaymentPayerAddress struct {
+	Zip_code      string `json:"zip_code,omitempty"`
+	Street_name   string `json:"street_name,omitempty"`
+	Street_number string `json:"street_number,omitempty"`
+	Neighborhood  string `json:"neighborhood,omitempty"`
+	City          string `json:"city,omitempty"`
+	Federal_unit  string `json:"federal_unit,omitempty"`
+}
+
+type PaymentAdditionalInfo struct {
+	Ip_address string                   `json:"ip_address,omitempty"`
+	Items      []PaymentItem            `json:"items,omitempty"`
+	Payer      *AdditionalInfoPayer     `json:"payer,omitempty"`
+	Shipments  *AdditionalInfoShipments `json:"shipments,omitempty"`
+}
+
+type PaymentItem struct {
+	Id          string  `json:"id,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Picture_url string  `json:"picture_url,omitempty"`
+	Category_id string  `json:"category_id,omitempty"`
+	Quantity    int     `json:"quantity,omitempty"`
+	Unit_price  float64 `json:"unit_price,omitempty"`
+}
+
+// UnmarshalJSON accepts quantity and unit_price both as numbers and as
+// quoted numbers, since MercadoPago echoes them back as strings.
+func (i *PaymentItem) UnmarshalJSON(data []byte) error {
+	type item PaymentItem
+	var r struct {
+		item
+		Quantity   json.RawMessage `json:"quantity"`
+		Unit_price json.RawMessage `json:"unit_price"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	*i = PaymentItem(r.item)
+
+	if s := unquoteNumber(r.Quantity); s != "" {
+		q, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		i.Quantity = q
+	}
+
+	if s := unquoteNumber(r.Unit_price); s != "" {
+		p, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		i.Unit_price = p
+	}
+
+	return nil
+}
+
+func unquoteNumber(raw json.RawMessage) string {
+	raw = bytes.Trim(raw, `"`)
+	if string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+type AdditionalInfoPayer struct {
+	First_name        string   `json:"first_name,omitempty"`
+	Last_name         string   `json:"last_name,omitempty"`
+	Phone             *Phone   `json:"phone,omitempty"`
+	Address           *Address `json:"address,omitempty"`
+	Registration_date string   `json:"registration_date,omitempty"`
+}
+
+type AdditionalInfoShipments struct {
+	Receiver_address struct {
+		Zip_code      string `json:"zip_code,omitempty"`
+		State_name    string `json:"state_name,omitempty"`
+		City_name     string `json:"city_name,omitempty"`
+		Street_name   string `json:"street_name,omitempty"`
+		Street_number string `json:"street_number,omitempty"`
+		Floor         string `json:"floor,omitempty"`
+		Apartment     string `json:"apartment,omitempty"`
+	} `json:"receiver_address"`
+}
+
+// Payment is the payment resource returned by the /v1/payments endpoints.
+type Payment struct {
+	Id                          int                    `json:"id"`
+	Client_id                   string                 `json:"client_id"`
+	Collector_id                int                    `json:"collector_id"`
+	Date_created                string                 `json:"date_created"`
+	Date_approved               string                 `json:"date_approved"`
+	Date_last_updated           string                 `json:"date_last_updated"`
+	Date_of_expiration          string                 `json:"date_of_expiration"`
+	Money_release_date          string                 `json:"money_release_date"`
+	Operation_type              string                 `json:"operation_type"`
+	Issuer_id                   string                 `json:"issuer_id"`
+	Payment_method_id           string                 `json:"payment_method_id"`
+	Payment_type_id             string                 `json:"payment_type_id"`
+	Status                      string                 `json:"status"`
+	Status_detail               string                 `json:"status_detail"`
+	Currency_id                 string                 `json:"currency_id"`
+	Description                 string                 `json:"description"`
+	Live_mode                   bool                   `json:"live_mode"`
+	Authorization_code          string                 `json:"authorization_code"`
+	External_reference          string                 `json:"external_reference"`
+	Installments                int                    `json:"installments"`
+	Transaction_amount          float64                `json:"transaction_amount"`
+	Transaction_amount_refunded float64                `json:"transaction_amount_refunded"`
+	Coupon_amount               float64                `json:"coupon_amount"`
+	Transaction_details         TransactionDetails     `json:"transaction_details"`
+	Fee_details                 []FeeDetail            `json:"fee_details"`
+	Refunds                     []Refund               `json:"refunds"`
+	Captured                    bool                   `json:"captured"`
+	Binary_mode                 bool                   `json:"binary_mode"`
+	Statement_descriptor        string                 `json:"statement_descriptor"`
+	Notification_url            string                 `json:"notification_url"`
+	Order                       PaymentOrder           `json:"order"`
+	Payer                       PaymentPayer           `json:"payer"`
+	Card                        PaymentCard            `json:"card"`
+	Point_of_interaction        PointOfInteraction     `json:"point_of_interaction"`
+	Barcode                     PaymentBarcode         `json:"barcode"`
+	Additional_info             PaymentAdditionalInfo  `json:"additional_info"`
+	Metadata                    map[string]interface{} `json:"metadata"`
+}
+
+// AwaitingPayment reports whether the payment waits for the payer to pay a
+// ticket or a transfer, as offline and Pix payments do once created.
+func (p Payment) AwaitingPayment() bool {
+	return p.Status == PaymentStatusPending &&
+		(p.Status_detail == StatusDetailPendingWaitingPayment || p.Status_detail == StatusDetailPendingWaitingTransfer)
+}
+
+// Expired reports whether the payment was cancelled because it wasn't paid
+// before its date of expiration.
+func (p Payment) Expired() bool {
+	return p.Status == PaymentStatusCancelled && p.Status_detail == StatusDetailExpired
+}
+
+// Expiration returns the parsed Date_of_expiration, or the zero time when it
+// is missing.
+func (p Payment) Expiration() time.Time {
+	return parseDate(p.Date_of_expiration)
+}
+
+// parseDate parses a MercadoPago date, returning the zero time when it is
+// empty or malformed.
+func parseDate(s string) time.Time {
+	if t, err := time.Parse(_dateLayout, s); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+type PaymentOrder struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// PointOfInteraction holds the data the payer needs to pay, such as the QR
+// code of Pix payments.
+type PointOfInteraction struct {
+	Type             string          `json:"type"`
+	Sub_type         string          `json:"sub_type"`
+	Transaction_data TransactionData `json:"transaction_data"`
+}
+
+type TransactionData struct {
+	Qr_code          string `json:"qr_code"`
+	Qr_code_base64   string `json:"qr_code_base64"`
+	Ticket_url       string `json:"ticket_url"`
+	Transaction_id   string `json:"transaction_id"`
+	Bank_transfer_id int64  `json:"bank_transfer_id"`
+	E2e_id           string `json:"e2e_id"`
+}
+
+type TransactionDetails struct {
+	Net_received_amount float64 `json:"net_received_amount"`
+	Total_paid_amount   float64 `json:"total_paid_amount"`
+	Overpaid_amount     float64 `json:"overpaid_amount"`
+	Installment_amount  float64 `json:"installment_amount"`
+	// External_resource_url is the ticket the payer pays offline payments
+	// with, e.g. the boleto PDF.
+	External_resource_url       string         `json:"external_resource_url"`
+	Payment_method_reference_id string         `json:"payment_method_reference_id"`
+	Digitable_line              string         `json:"digitable_line"`
+	Verification_code           string         `json:"verification_code"`
+	Financial_institution       string         `json:"financial_institution"`
+	Barcode                     PaymentBarcode `json:"barcode"`
+}
+
+type PaymentBarcode struct {
+	Content string `json:"content"`
+}
+
+type FeeDetail struct {
+	Type      string  `json:"type"`
+	Amount    float64 `json:"amount"`
+	Fee_payer string  `json:"fee_payer"`
+}
+
+type PaymentCard struct {
+	Id               string `json:"id"`
+	First_six_digits string `json:"first_six_digits"`
+	Last_four_digits string `json:"last_four_digits"`
+	Expiration_month int    `json:"expiration_month"`
+	Expiration_year  int    `json:"expiration_year"`
+	Date_created     string `json:"date_created"`
+	Cardholder       struct {
+		Name           string         `json:"name"`
+		Identification Identification `json:"identification"`
+	} `json:"cardholder"`
+}
+
+func (g *Gateway) CreatePayment(accessToken string, payment NewPayment) (Payment, error) {
+	return g.CreatePaymentWithContext(context.Background(), accessToken, payment)
+}
+
+func (g *Gateway) CreatePaymentWithContext(ctx context.Context, accessToken string, payment NewPayment) (created Payment, err error) {
+	err = g.doJSON(ctx, http.MethodPost, "/v1/payments", accessToken, payment, &created)
+	return
+}
+
+func (g *Gateway) UpdatePayment(accessToken string, id string, update PaymentUpdate) (Payment, error) {
+	return g.UpdatePaymentWithContext(context.Background(), accessToken, id, update)
+}
+
+func (g *Gateway) UpdatePaymentWithContext(ctx context.Context, accessToken string, id string, update PaymentUpdate) (updated Payment, err error) {
+	err = g.doJSON(ctx, http.MethodPut, "/v1/payments/"+id, accessToken, update, &updated)
+	return
+}
+
+// CapturePayment captures a card payment created with Capture set to false.
+func (g *Gateway) CapturePayment(accessToken string, id string) (Payment, error) {
+	return g.CapturePaymentWithContext(context.Background(), accessToken, id)
+}
+
+func (g *Gateway) CapturePaymentWithContext(ctx context.Context, accessToken string, id string) (Payment, error) {
+	capture := true
+	return g.UpdatePaymentWithContext(ctx, accessToken, id, PaymentUpdate{Capture: &capture})
+}
+
+// CancelPayment cancels a pending, in_process or authorized payment.
+func (g *Gateway) CancelPayment(accessToken string, id string) (Payment, error) {
+	return g.CancelPaymentWithContext(context.Background(), accessToken, id)
+}
+
+func (g *Gateway) CancelPaymentWithContext(ctx context.Context, accessToken string, id string) (Payment, error) {
+	return g.UpdatePaymentWithContext(ctx, accessToken, id, PaymentUpdate{Status: PaymentStatusCancelled})
+}
@@ -0,0 +1,394 @@
+package mercadopago
+
+import (
+	"container/heap"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	_defaultPaymentMethodCacheTTL  = time.Hour
+	_defaultPaymentMethodCacheSize = 1000
+)
+
+// Payer cost labels.
+const (
+	PayerCostLabelRecommended  = "recommended_installment"
+	PayerCostLabelInterestFree = "no_interest"
+)
+
+// PaymentMethod is a payment method available to the seller, as returned by
+// /v1/payment_methods.
+type PaymentMethod struct {
+	Id                     string                  `json:"id"`
+	Name                   string                  `json:"name"`
+	Payment_type_id        string                  `json:"payment_type_id"`
+	Status                 string                  `json:"status"`
+	Thumbnail              string                  `json:"thumbnail"`
+	Secure_thumbnail       string                  `json:"secure_thumbnail"`
+	Deferred_capture       string                  `json:"deferred_capture"`
+	Settings               []PaymentMethodSettings `json:"settings"`
+	Additional_info_needed []string                `json:"additional_info_needed"`
+	Min_allowed_amount     float64                 `json:"min_allowed_amount"`
+	Max_allowed_amount     float64                 `json:"max_allowed_amount"`
+	Accreditation_time     int                     `json:"accreditation_time"`
+	Processing_modes       []string                `json:"processing_modes"`
+	Financial_institutions []struct {
+		Id          string `json:"id"`
+		Description string `json:"description"`
+	} `json:"financial_institutions"`
+}
+
+// PaymentMethodSettings tells how to validate the cards of a payment method.
+type PaymentMethodSettings struct {
+	Card_number struct {
+		Validation string `json:"validation"`
+		Length     int    `json:"length"`
+	} `json:"card_number"`
+	Bin struct {
+		Pattern              string `json:"pattern"`
+		Installments_pattern string `json:"installments_pattern"`
+		Exclusion_pattern    string `json:"exclusion_pattern"`
+	} `json:"bin"`
+	Security_code struct {
+		Length        int    `json:"length"`
+		Card_location string `json:"card_location"`
+		Mode          string `json:"mode"`
+	} `json:"security_code"`
+}
+
+// CardIssuer is a bank issuing cards of a payment method.
+type CardIssuer struct {
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	Status           string `json:"status"`
+	Thumbnail        string `json:"thumbnail"`
+	Secure_thumbnail string `json:"secure_thumbnail"`
+	Processing_mode  string `json:"processing_mode"`
+}
+
+// Installments are the installment options of a payment method and issuer.
+type Installments struct {
+	Payment_method_id string      `json:"payment_method_id"`
+	Payment_type_id   string      `json:"payment_type_id"`
+	Processing_mode   string      `json:"processing_mode"`
+	Issuer            CardIssuer  `json:"issuer"`
+	Payer_costs       []PayerCost `json:"payer_costs"`
+}
+
+// PayerCost is an installment option: the number of installments, their
+// rate and the amounts the payer pays.
+type PayerCost struct {
+	Installments        int      `json:"installments"`
+	Installment_rate    float64  `json:"installment_rate"`
+	Discount_rate       float64  `json:"discount_rate"`
+	Labels              []string `json:"labels"`
+	Min_allowed_amount  float64  `json:"min_allowed_amount"`
+	Max_allowed_amount  float64  `json:"max_allowed_amount"`
+	Recommended_message string   `json:"recommended_message"`
+	Installment_amount  float64  `json:"installment_amount"`
+	Total_amount        float64  `json:"total_amount"`
+}
+
+// HasLabel reports whether the option has the given label, e.g.
+// PayerCostLabelRecommended.
+func (p PayerCost) HasLabel(label string) bool {
+	for _, l := range p.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Rates returns the rates MercadoPago sends as a label such as
+// "CFT_45,79%|TEA_39,62%", keyed by name.
+func (p PayerCost) Rates() map[string]string {
+	rates := map[string]string{}
+	for _, l := range p.Labels {
+		if !strings.Contains(l, "_") || !strings.Contains(l, "%") {
+			continue
+		}
+		for _, part := range strings.Split(l, "|") {
+			kv := strings.SplitN(part, "_", 2)
+			if len(kv) == 2 {
+				rates[kv[0]] = kv[1]
+			}
+		}
+	}
+	return rates
+}
+
+// MaxInstallments returns the largest number of installments offered, to
+// fill Payment_methods.Installments of a preference.
+func (i Installments) MaxInstallments() int {
+	max := 0
+	for _, p := range i.Payer_costs {
+		if p.Installments > max {
+			max = p.Installments
+		}
+	}
+	return max
+}
+
+// MaxInterestFreeInstallments returns the largest number of installments
+// offered without interest.
+func (i Installments) MaxInterestFreeInstallments() int {
+	max := 0
+	for _, p := range i.Payer_costs {
+		if p.Installment_rate == 0 && p.Installments > max {
+			max = p.Installments
+		}
+	}
+	return max
+}
+
+// ExcludedPaymentMethods returns the methods not in allowed, to fill
+// Payment_methods.Excluded_payment_methods of a preference.
+func ExcludedPaymentMethods(methods []PaymentMethod, allowed ...string) []Excluded_payment_methods {
+	keep := map[string]bool{}
+	for _, id := range allowed {
+		keep[id] = true
+	}
+
+	var excluded []Excluded_payment_methods
+	for _, m := range methods {
+		if !keep[m.Id] {
+			excluded = append(excluded, Excluded_payment_methods{Id: m.Id})
+		}
+	}
+	return excluded
+}
+
+// InstallmentsQuery selects the installments to look up. Amount and either
+// Bin, the first digits of the card, or Payment_method_id are required.
+type InstallmentsQuery struct {
+	Amount            float64
+	Bin               string
+	Payment_method_id string
+	Payment_type_id   string
+	Issuer_id         string
+}
+
+func (q InstallmentsQuery) query() string {
+	v := url.Values{}
+	v.Set("amount", strconv.FormatFloat(q.Amount, 'f', -1, 64))
+	if q.Bin != "" {
+		v.Set("bin", q.Bin)
+	}
+	if q.Payment_method_id != "" {
+		v.Set("payment_method_id", q.Payment_method_id)
+	}
+	if q.Payment_type_id != "" {
+		v.Set("payment_type_id", q.Payment_type_id)
+	}
+	if q.Issuer_id != "" {
+		v.Set("issuer.id", q.Issuer_id)
+	}
+	return v.Encode()
+}
+
+func (g *Gateway) GetPaymentMethods(accessToken string) ([]PaymentMethod, error) {
+	return g.GetPaymentMethodsWithContext(context.Background(), accessToken)
+}
+
+func (g *Gateway) GetPaymentMethodsWithContext(ctx context.Context, accessToken string) (methods []PaymentMethod, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/payment_methods", accessToken, nil, &methods)
+	return
+}
+
+// GetCardIssuers returns the issuers of a card payment method, narrowed down
+// to the issuer of a card when its bin is given.
+func (g *Gateway) GetCardIssuers(accessToken string, paymentMethodID string, bin string) ([]CardIssuer, error) {
+	return g.GetCardIssuersWithContext(context.Background(), accessToken, paymentMethodID, bin)
+}
+
+func (g *Gateway) GetCardIssuersWithContext(ctx context.Context, accessToken string, paymentMethodID string, bin string) (issuers []CardIssuer, err error) {
+	q := url.Values{}
+	q.Set("payment_method_id", paymentMethodID)
+	if bin != "" {
+		q.Set("bin", bin)
+	}
+
+	err = g.doJSON(ctx, http.MethodGet, "/v1/payment_methods/card_issuers?"+q.Encode(), accessToken, nil, &issuers)
+	return
+}
+
+func (g *Gateway) GetInstallments(accessToken string, query InstallmentsQuery) ([]Installments, error) {
+	return g.GetInstallmentsWithContext(context.Background(), accessToken, query)
+}
+
+func (g *Gateway) GetInstallmentsWithContext(ctx context.Context, accessToken string, query InstallmentsQuery) (installments []Installments, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/payment_methods/installments?"+query.query(), accessToken, nil, &installments)
+	return
+}
+
+// PaymentMethodCache keeps the payment methods, issuers and installments
+// looked up through Client for TTL, per access token and query. Access tokens
+// are only kept hashed. Each call returns its own copy, so callers may modify
+// the results.
+type PaymentMethodCache struct {
+	Client ClientGateway
+	// TTL is how long lookups are kept; it defaults to one hour.
+	TTL time.Duration
+	// MaxEntries bounds the number of lookups kept; it defaults to 1000.
+	// Installments are kept per amount, so a checkout sees many of them.
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	queue   cacheQueue
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+	index   int
+}
+
+// cacheQueue is a container/heap of the cache entries, the one expiring
+// first on top.
+type cacheQueue []*cacheEntry
+
+func (q cacheQueue) Len() int           { return len(q) }
+func (q cacheQueue) Less(i, j int) bool { return q[i].expires.Before(q[j].expires) }
+
+func (q cacheQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *cacheQueue) Push(x interface{}) {
+	e := x.(*cacheEntry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *cacheQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return e
+}
+
+func NewPaymentMethodCache(client ClientGateway, ttl time.Duration) *PaymentMethodCache {
+	return &PaymentMethodCache{
+		Client: client,
+		TTL:    ttl,
+	}
+}
+
+func (c *PaymentMethodCache) PaymentMethods(ctx context.Context, accessToken string) ([]PaymentMethod, error) {
+	var methods []PaymentMethod
+	err := c.get(tokenKey(accessToken)+"|payment_methods", &methods, func() (interface{}, error) {
+		return c.Client.GetPaymentMethodsWithContext(ctx, accessToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
+func (c *PaymentMethodCache) CardIssuers(ctx context.Context, accessToken string, paymentMethodID string, bin string) ([]CardIssuer, error) {
+	var issuers []CardIssuer
+	err := c.get(tokenKey(accessToken)+"|card_issuers|"+paymentMethodID+"|"+bin, &issuers, func() (interface{}, error) {
+		return c.Client.GetCardIssuersWithContext(ctx, accessToken, paymentMethodID, bin)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issuers, nil
+}
+
+func (c *PaymentMethodCache) Installments(ctx context.Context, accessToken string, query InstallmentsQuery) ([]Installments, error) {
+	var installments []Installments
+	err := c.get(tokenKey(accessToken)+"|installments|"+query.query(), &installments, func() (interface{}, error) {
+		return c.Client.GetInstallmentsWithContext(ctx, accessToken, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+// tokenKey is the part of the cache keys identifying the access token, so
+// tokens aren't kept in memory longer than their lookups need them.
+func tokenKey(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// get decodes the cached value of key into out, calling fetch when it is
+// missing or expired. Values are kept encoded, so every caller decodes its
+// own copy. Errors aren't cached.
+func (c *PaymentMethodCache) get(key string, out interface{}, fetch func() (interface{}, error)) error {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && now().Before(e.expires) {
+		c.mu.Unlock()
+		return json.Unmarshal(e.value, out)
+	}
+	c.mu.Unlock()
+
+	v, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = _defaultPaymentMethodCacheTTL
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		heap.Remove(&c.queue, e.index)
+		delete(c.entries, key)
+	}
+	c.evict(now())
+	e := &cacheEntry{key: key, value: b, expires: now().Add(ttl)}
+	heap.Push(&c.queue, e)
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	return json.Unmarshal(b, out)
+}
+
+// evict drops the expired entries and, when the cache is still full, the one
+// expiring first. It must be called with mu held.
+func (c *PaymentMethodCache) evict(now time.Time) {
+	if c.entries == nil {
+		c.entries = map[string]*cacheEntry{}
+	}
+
+	max := c.MaxEntries
+	if max <= 0 {
+		max = _defaultPaymentMethodCacheSize
+	}
+
+	for len(c.queue) > 0 && (!now.Before(c.queue[0].expires) || len(c.queue) >= max) {
+		e := heap.Pop(&c.queue).(*cacheEntry)
+		delete(c.entries, e.key)
+	}
+}
@@ -0,0 +1,219 @@
+package mercadopago
+
+import (
+    "bytes"
+    "context"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+    "time"
+)
+
+const _installmentsResponse = `[{"payment_method_id": "visa", "payment_type_id": "credit_card", "issuer": {"id": "25", "name": "Visa"}, "processing_mode": "aggregator", "payer_costs": [{"installments": 1, "installment_rate": 0, "labels": ["CFT_0,00%|TEA_0,00%"], "installment_amount": 100, "total_amount": 100}, {"installments": 3, "installment_rate": 0, "labels": ["recommended_installment", "CFT_0,00%|TEA_0,00%"], "recommended_message": "3 cuotas de $ 33,33 ($ 100,00)", "installment_amount": 33.33, "total_amount": 100}, {"installments": 12, "installment_rate": 45.5, "labels": ["CFT_66,82%|TEA_56,08%"], "installment_amount": 12.13, "total_amount": 145.5}]}]`
+
+func TestGateway_GetPaymentMethods(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id": "visa", "name": "Visa", "payment_type_id": "credit_card", "status": "active", "settings": [{"card_number": {"validation": "standard", "length": 16}, "bin": {"pattern": "^4"}, "security_code": {"length": 3, "card_location": "back", "mode": "mandatory"}}], "min_allowed_amount": 0.5, "max_allowed_amount": 60000}, {"id": "pix", "payment_type_id": "bank_transfer", "status": "active"}]`))),
+    }
+    // When
+    methods, err := g.GetPaymentMethods("MY_ACCESS_TOKEN")
+
+    // Then
+    require.NoError(t, err)
+    require.Len(t, methods, 2)
+    require.Equal(t, "visa", methods[0].Id)
+    require.Equal(t, 16, methods[0].Settings[0].Card_number.Length)
+    require.Equal(t, "^4", methods[0].Settings[0].Bin.Pattern)
+    require.Equal(t, float64(60000), methods[0].Max_allowed_amount)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/v1/payment_methods", c.req.URL.Path)
+}
+
+func TestGateway_GetCardIssuers(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id": "25", "name": "Visa", "processing_mode": "aggregator"}]`))),
+    }
+    // When
+    issuers, err := g.GetCardIssuers("MY_ACCESS_TOKEN", "visa", "450995")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "25", issuers[0].Id)
+    require.Equal(t, "/v1/payment_methods/card_issuers", c.req.URL.Path)
+    require.Equal(t, "bin=450995&payment_method_id=visa", c.req.URL.RawQuery)
+}
+
+func TestGateway_GetInstallments(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(_installmentsResponse))),
+    }
+    // When
+    installments, err := g.GetInstallments("MY_ACCESS_TOKEN", InstallmentsQuery{Amount: 100.5, Bin: "450995"})
+
+    // Then
+    require.NoError(t, err)
+    require.Len(t, installments, 1)
+    require.Equal(t, "25", installments[0].Issuer.Id)
+    require.Len(t, installments[0].Payer_costs, 3)
+    require.Equal(t, "/v1/payment_methods/installments", c.req.URL.Path)
+    require.Equal(t, "amount=100.5&bin=450995", c.req.URL.RawQuery)
+
+    i := installments[0]
+    require.Equal(t, 12, i.MaxInstallments())
+    require.Equal(t, 3, i.MaxInterestFreeInstallments())
+    require.True(t, i.Payer_costs[1].HasLabel(PayerCostLabelRecommended))
+    require.False(t, i.Payer_costs[2].HasLabel(PayerCostLabelRecommended))
+    require.Equal(t, map[string]string{"CFT": "66,82%", "TEA": "56,08%"}, i.Payer_costs[2].Rates())
+}
+
+func TestExcludedPaymentMethods(t *testing.T) {
+    // Given
+    methods := []PaymentMethod{{Id: "visa"}, {Id: "master"}, {Id: "bolbradesco"}, {Id: "pix"}}
+
+    // When
+    excluded := ExcludedPaymentMethods(methods, "visa", "pix")
+
+    // Then
+    require.Equal(t, []Excluded_payment_methods{{Id: "master"}, {Id: "bolbradesco"}}, excluded)
+}
+
+func TestPaymentMethodCache(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, _installmentsResponse),
+            newResponse(http.StatusOK, `[]`),
+            newResponse(http.StatusOK, _installmentsResponse),
+        },
+    }
+    now := time.Now()
+    cache := NewPaymentMethodCache(&Gateway{Client: c}, time.Minute)
+    cache.now = func() time.Time { return now }
+    query := InstallmentsQuery{Amount: 100, Payment_method_id: "visa"}
+
+    // When
+    first, err := cache.Installments(context.Background(), "MY_ACCESS_TOKEN", query)
+    require.NoError(t, err)
+    second, err := cache.Installments(context.Background(), "MY_ACCESS_TOKEN", query)
+    require.NoError(t, err)
+    other, err := cache.Installments(context.Background(), "MY_ACCESS_TOKEN", InstallmentsQuery{Amount: 200, Payment_method_id: "visa"})
+    require.NoError(t, err)
+
+    // Then
+    require.Equal(t, first, second)
+    require.Empty(t, other)
+    require.Equal(t, 2, c.calls)
+    for key := range cache.entries {
+        require.NotContains(t, key, "MY_ACCESS_TOKEN")
+    }
+
+    // When
+    now = now.Add(2 * time.Minute)
+    _, err = cache.Installments(context.Background(), "MY_ACCESS_TOKEN", query)
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 3, c.calls)
+}
+
+func TestPaymentMethodCache_DoesNotCacheErrors(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusInternalServerError, `{"message": "internal server error"}`),
+            newResponse(http.StatusOK, `[{"id": "visa"}]`),
+        },
+    }
+    cache := NewPaymentMethodCache(&Gateway{Client: c}, time.Minute)
+
+    // When
+    _, err := cache.PaymentMethods(context.Background(), "MY_ACCESS_TOKEN")
+    require.EqualError(t, err, "internal server error")
+    methods, err := cache.PaymentMethods(context.Background(), "MY_ACCESS_TOKEN")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "visa", methods[0].Id)
+    require.Equal(t, 2, c.calls)
+}
+
+func TestPaymentMethodCache_ReturnsCopies(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, _installmentsResponse),
+        },
+    }
+    cache := NewPaymentMethodCache(&Gateway{Client: c}, time.Minute)
+    query := InstallmentsQuery{Amount: 100, Payment_method_id: "visa"}
+
+    // When
+    first, err := cache.Installments(context.Background(), "MY_ACCESS_TOKEN", query)
+    require.NoError(t, err)
+    first[0].Payer_costs[0].Installments = 99
+    first[0].Payer_costs[1].Labels[0] = "changed"
+    second, err := cache.Installments(context.Background(), "MY_ACCESS_TOKEN", query)
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 1, second[0].Payer_costs[0].Installments)
+    require.Equal(t, "recommended_installment", second[0].Payer_costs[1].Labels[0])
+    require.Equal(t, 1, c.calls)
+}
+
+func TestPaymentMethodCache_Evicts(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, `[]`),
+            newResponse(http.StatusOK, `[]`),
+            newResponse(http.StatusOK, `[]`),
+            newResponse(http.StatusOK, `[]`),
+        },
+    }
+    now := time.Now()
+    cache := NewPaymentMethodCache(&Gateway{Client: c}, time.Minute)
+    cache.MaxEntries = 2
+    cache.now = func() time.Time { return now }
+    installments := func(amount float64) {
+        _, err := cache.Installments(context.Background(), "MY_ACCESS_TOKEN", InstallmentsQuery{Amount: amount, Payment_method_id: "visa"})
+        require.NoError(t, err)
+    }
+
+    // When
+    installments(100)
+    now = now.Add(time.Second)
+    installments(200)
+    now = now.Add(time.Second)
+    installments(300)
+
+    // Then
+    require.Len(t, cache.entries, 2)
+    installments(300)
+    installments(200)
+    require.Equal(t, 3, c.calls)
+
+    // When
+    now = now.Add(2 * time.Minute)
+    installments(400)
+
+    // Then
+    require.Len(t, cache.entries, 1)
+    require.Equal(t, 4, c.calls)
+}
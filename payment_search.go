@@ -0,0 +1,149 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Date fields a payment search range can apply to.
+const (
+	PaymentSearchRangeDateCreated      = "date_created"
+	PaymentSearchRangeDateApproved     = "date_approved"
+	PaymentSearchRangeDateLastUpdated  = "date_last_updated"
+	PaymentSearchRangeMoneyReleaseDate = "money_release_date"
+)
+
+// PaymentSearch filters a payments search. Empty fields are left out of the
+// query.
+type PaymentSearch struct {
+	Id                 string
+	Status             string
+	Status_detail      string
+	External_reference string
+	Operation_type     string
+	Payment_type_id    string
+	Payment_method_id  string
+	Payer_id           string
+	Payer_email        string
+	Collector_id       string
+	Store_id           string
+	Pos_id             string
+	// Range is the date field, one of the PaymentSearchRange constants, that
+	// Begin_date and End_date apply to. The dates take either ISO 8601 times
+	// or relative ones such as NOW-30DAYS.
+	Range      string
+	Begin_date string
+	End_date   string
+	// Sort is the field results are sorted by, e.g. date_created, and
+	// Criteria the order, asc or desc.
+	Sort     string
+	Criteria string
+	Limit    int
+	Offset   int
+}
+
+func (s PaymentSearch) query() string {
+	q := url.Values{}
+	set := func(key string, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("id", s.Id)
+	set("status", s.Status)
+	set("status_detail", s.Status_detail)
+	set("external_reference", s.External_reference)
+	set("operation_type", s.Operation_type)
+	set("payment_type_id", s.Payment_type_id)
+	set("payment_method_id", s.Payment_method_id)
+	set("payer.id", s.Payer_id)
+	set("payer.email", s.Payer_email)
+	set("collector.id", s.Collector_id)
+	set("store_id", s.Store_id)
+	set("pos_id", s.Pos_id)
+	set("range", s.Range)
+	set("begin_date", s.Begin_date)
+	set("end_date", s.End_date)
+	set("sort", s.Sort)
+	set("criteria", s.Criteria)
+	if s.Limit > 0 {
+		q.Set("limit", strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		q.Set("offset", strconv.Itoa(s.Offset))
+	}
+	return q.Encode()
+}
+
+// PaymentSearchResponse is a page of payments returned by /v1/payments/search.
+type PaymentSearchResponse struct {
+	Paging  Paging    `json:"paging"`
+	Results []Payment `json:"results"`
+}
+
+func (g *Gateway) SearchPayments(accessToken string, search PaymentSearch) (PaymentSearchResponse, error) {
+	return g.SearchPaymentsWithContext(context.Background(), accessToken, search)
+}
+
+func (g *Gateway) SearchPaymentsWithContext(ctx context.Context, accessToken string, search PaymentSearch) (payments PaymentSearchResponse, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/payments/search?"+search.query(), accessToken, nil, &payments)
+	return
+}
+
+// PaymentIterator returns an iterator over every payment matching search,
+// starting at search.Offset.
+func (g *Gateway) PaymentIterator(accessToken string, search PaymentSearch) *PaymentIterator {
+	return newPaymentIterator(search, func(ctx context.Context, search PaymentSearch) (PaymentSearchResponse, error) {
+		return g.SearchPaymentsWithContext(ctx, accessToken, search)
+	})
+}
+
+// PaymentIterator walks the pages of a payments search, fetching the next one
+// when the current one is used up:
+//
+//	it := g.PaymentIterator(accessToken, search)
+//	for it.Next(ctx) {
+//		payment := it.Payment()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type PaymentIterator struct {
+	pager
+	page    []Payment
+	current Payment
+}
+
+func newPaymentIterator(search PaymentSearch, fetch func(ctx context.Context, search PaymentSearch) (PaymentSearchResponse, error)) *PaymentIterator {
+	it := &PaymentIterator{}
+	it.pager = pager{
+		offset: search.Offset,
+		fetch: func(ctx context.Context, offset int) (int, int, error) {
+			search.Offset = offset
+			resp, err := fetch(ctx, search)
+			if err != nil {
+				return 0, 0, err
+			}
+			it.page = resp.Results
+			return len(resp.Results), resp.Paging.Total, nil
+		},
+	}
+	return it
+}
+
+// Next advances to the next payment, reporting false once all of them were
+// read or a page couldn't be fetched.
+func (it *PaymentIterator) Next(ctx context.Context) bool {
+	i, ok := it.pager.next(ctx)
+	if ok {
+		it.current = it.page[i]
+	}
+	return ok
+}
+
+// Payment returns the payment Next advanced to.
+func (it *PaymentIterator) Payment() Payment {
+	return it.current
+}
@@ -0,0 +1,143 @@
+package mercadopago
+
+import (
+    "bytes"
+    "context"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_SearchPayments(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"total": 31, "limit": 30, "offset": 30}, "results": [{"id": 123, "status": "approved", "external_reference": "ORDER 1/2", "payer": {"email": "test_user@testuser.com", "identification": {"type": "CPF", "number": "19119119100"}}}]}`))),
+    }
+    // When
+    payments, err := g.SearchPayments("MY_ACCESS_TOKEN", PaymentSearch{
+        Status:             PaymentStatusApproved,
+        External_reference: "ORDER 1/2",
+        Payment_type_id:    "credit_card",
+        Payer_email:        "test_user+1@testuser.com",
+        Range:              PaymentSearchRangeDateApproved,
+        Begin_date:         "NOW-30DAYS",
+        End_date:           "NOW",
+        Sort:               "date_approved",
+        Criteria:           "asc",
+        Limit:              30,
+        Offset:             30,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, Paging{Offset: 30, Limit: 30, Total: 31}, payments.Paging)
+    require.Equal(t, 123, payments.Results[0].Id)
+    require.Equal(t, "19119119100", payments.Results[0].Payer.Identification.Number)
+    require.Equal(t, "/v1/payments/search", c.req.URL.Path)
+    require.Equal(t, "Bearer MY_ACCESS_TOKEN", c.req.Header.Get("Authorization"))
+
+    q := c.req.URL.Query()
+    require.Equal(t, "approved", q.Get("status"))
+    require.Equal(t, "ORDER 1/2", q.Get("external_reference"))
+    require.Equal(t, "credit_card", q.Get("payment_type_id"))
+    require.Equal(t, "test_user+1@testuser.com", q.Get("payer.email"))
+    require.Equal(t, "date_approved", q.Get("range"))
+    require.Equal(t, "NOW-30DAYS", q.Get("begin_date"))
+    require.Equal(t, "NOW", q.Get("end_date"))
+    require.Equal(t, "date_approved", q.Get("sort"))
+    require.Equal(t, "asc", q.Get("criteria"))
+    require.Equal(t, "30", q.Get("limit"))
+    require.Equal(t, "30", q.Get("offset"))
+    require.NotContains(t, q, "payer.id")
+}
+
+func TestGateway_GetPaymentsSearch_EscapesExternalReference(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"total": 1}, "results": [{"id": 123}]}`))),
+    }
+    // When
+    payments, err := g.GetPaymentsSearch("MY_ACCESS_TOKEN", "ID_a9XY6Qd+aKTswbX2sdZQ/B0Mzs8pSWnzynl&status=approved")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 1, payments.Paging.Total)
+
+    q := c.req.URL.Query()
+    require.Equal(t, "ID_a9XY6Qd+aKTswbX2sdZQ/B0Mzs8pSWnzynl&status=approved", q.Get("external_reference"))
+    require.Empty(t, q.Get("status"))
+    require.Equal(t, "date_created", q.Get("sort"))
+    require.Equal(t, "desc", q.Get("criteria"))
+}
+
+func TestGateway_GetTotalPayments_Query(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"total": 100, "limit": 1, "offset": 0}}`))),
+    }
+    // When
+    _, err := g.GetTotalPayments("MY_ACCESS_TOKEN", "approved")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "limit=1&status=approved", c.req.URL.RawQuery)
+    require.Equal(t, "Bearer MY_ACCESS_TOKEN", c.req.Header.Get("Authorization"))
+}
+
+func TestPaymentIterator(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, `{"paging": {"offset": 0, "limit": 2, "total": 3}, "results": [{"id": 1}, {"id": 2}]}`),
+            newResponse(http.StatusOK, `{"paging": {"offset": 2, "limit": 2, "total": 3}, "results": [{"id": 3}]}`),
+        },
+    }
+    g := &Gateway{Client: c}
+    it := g.PaymentIterator("MY_ACCESS_TOKEN", PaymentSearch{Status: PaymentStatusApproved, Limit: 2})
+
+    // When
+    var ids []int
+    for it.Next(context.Background()) {
+        ids = append(ids, it.Payment().Id)
+    }
+
+    // Then
+    require.NoError(t, it.Err())
+    require.Equal(t, []int{1, 2, 3}, ids)
+    require.Equal(t, 3, it.Total())
+    require.Equal(t, 2, c.calls)
+    require.Equal(t, "limit=2&offset=2&status=approved", c.reqs[1].URL.RawQuery)
+}
+
+func TestPaymentIterator_Error(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusTooManyRequests, `{"message": "too many requests"}`),
+        },
+    }
+    g := &Gateway{Client: c}
+    it := g.PaymentIterator("MY_ACCESS_TOKEN", PaymentSearch{})
+
+    // When
+    ok := it.Next(context.Background())
+
+    // Then
+    require.False(t, ok)
+    require.True(t, IsRateLimited(it.Err()))
+    require.False(t, it.Next(context.Background()))
+    require.Equal(t, 1, c.calls)
+}
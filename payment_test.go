@@ -0,0 +1,121 @@
+package mercadopago
+
+import (
+    "bytes"
+    "errors"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreatePayment(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "approved", "transaction_amount": 15.75, "additional_info": {"items": [{"quantity": "2", "unit_price": "7.5"}]}}`))),
+    }
+    // When
+    payment, err := g.CreatePayment("MY_ACCESS_TOKEN", newPayment())
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 123, payment.Id)
+    require.Equal(t, PaymentStatusApproved, payment.Status)
+    require.Equal(t, 15.75, payment.Transaction_amount)
+    require.Equal(t, 2, payment.Additional_info.Items[0].Quantity)
+    require.Equal(t, 7.5, payment.Additional_info.Items[0].Unit_price)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/v1/payments", c.req.URL.Path)
+    require.Equal(t, "Bearer MY_ACCESS_TOKEN", c.req.Header.Get("Authorization"))
+}
+
+func TestGateway_CreatePayment_MercadoPagoError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "400",
+        StatusCode: 400,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message": "invalid token"}`))),
+    }
+    // When
+    _, err := g.CreatePayment("MY_ACCESS_TOKEN", newPayment())
+
+    // Then
+    require.Error(t, err)
+    require.Equal(t, http.StatusBadRequest, getStatusCodeFromError(err))
+}
+
+func TestGateway_CreatePayment_DoError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.err = errors.New("do error")
+    // When
+    _, err := g.CreatePayment("MY_ACCESS_TOKEN", newPayment())
+
+    // Then
+    require.Error(t, err)
+    require.EqualError(t, err, "do error")
+}
+
+func TestGateway_CapturePayment(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "approved", "captured": true}`))),
+    }
+    // When
+    payment, err := g.CapturePayment("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.True(t, payment.Captured)
+    require.Equal(t, http.MethodPut, c.req.Method)
+    require.Equal(t, "/v1/payments/123", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"capture": true}`, string(b))
+}
+
+func TestGateway_CancelPayment(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "cancelled"}`))),
+    }
+    // When
+    payment, err := g.CancelPayment("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, PaymentStatusCancelled, payment.Status)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"status": "cancelled"}`, string(b))
+}
+
+func newPayment() NewPayment {
+    return NewPayment{
+        Transaction_amount: 15.75,
+        Token:              "CARD_TOKEN",
+        Description:        "sherlock",
+        Installments:       1,
+        Payment_method_id:  "visa",
+        Payer: PaymentPayer{
+            Email: "m@gmail.com",
+        },
+    }
+}
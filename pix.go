@@ -0,0 +1,101 @@
+package mercadopago
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+const PaymentMethodPix = "pix"
+
+// Pix QR codes can be paid for between 30 minutes and 30 days; MercadoPago
+// defaults to 24 hours.
+const (
+	PixMinExpiration = 30 * time.Minute
+	PixMaxExpiration = 30 * 24 * time.Hour
+)
+
+// NewPixPayment is the body of CreatePixPayment.
+type NewPixPayment struct {
+	Transaction_amount float64
+	Description        string
+	Payer              PaymentPayer
+	External_reference string
+	Notification_url   string
+	Metadata           map[string]interface{}
+	// Expires_in is how long the QR code can be paid for, between
+	// PixMinExpiration and PixMaxExpiration. Zero keeps the default.
+	Expires_in time.Duration
+}
+
+func (p NewPixPayment) payment(now time.Time) (NewPayment, error) {
+	payment := NewPayment{
+		Transaction_amount: p.Transaction_amount,
+		Description:        p.Description,
+		Payment_method_id:  PaymentMethodPix,
+		Payer:              p.Payer,
+		External_reference: p.External_reference,
+		Notification_url:   p.Notification_url,
+		Metadata:           p.Metadata,
+	}
+
+	if p.Expires_in != 0 {
+		if p.Expires_in < PixMinExpiration || p.Expires_in > PixMaxExpiration {
+			return NewPayment{}, fmt.Errorf("pix expiration must be between %v and %v, got %v", PixMinExpiration, PixMaxExpiration, p.Expires_in)
+		}
+		payment.Date_of_expiration = now.Add(p.Expires_in).Format(_dateLayout)
+	}
+
+	return payment, nil
+}
+
+// PixPayment is a Pix payment waiting to be paid with its QR code.
+type PixPayment struct {
+	Payment Payment
+	// Qr_code is the Pix copy and paste code.
+	Qr_code string
+	// Qr_code_base64 is a PNG image of the QR code, base64 encoded.
+	Qr_code_base64 string
+	Ticket_url     string
+	// Expiration is when the QR code stops being payable; it is zero if
+	// MercadoPago didn't send it.
+	Expiration time.Time
+}
+
+func newPixPayment(payment Payment) PixPayment {
+	data := payment.Point_of_interaction.Transaction_data
+
+	return PixPayment{
+		Payment:        payment,
+		Qr_code:        data.Qr_code,
+		Qr_code_base64: data.Qr_code_base64,
+		Ticket_url:     data.Ticket_url,
+		Expiration:     payment.Expiration(),
+	}
+}
+
+// QRCodeImage returns the PNG image of the QR code sent by MercadoPago. To
+// render it locally instead, see the pix/qr package.
+func (p PixPayment) QRCodeImage() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(p.Qr_code_base64)
+}
+
+// CreatePixPayment creates a Pix payment and returns its QR code.
+func (g *Gateway) CreatePixPayment(accessToken string, pix NewPixPayment) (PixPayment, error) {
+	return g.CreatePixPaymentWithContext(context.Background(), accessToken, pix)
+}
+
+func (g *Gateway) CreatePixPaymentWithContext(ctx context.Context, accessToken string, pix NewPixPayment) (PixPayment, error) {
+	payment, err := pix.payment(time.Now())
+	if err != nil {
+		return PixPayment{}, err
+	}
+
+	created, err := g.CreatePaymentWithContext(ctx, accessToken, payment)
+	if err != nil {
+		return PixPayment{}, err
+	}
+
+	return newPixPayment(created), nil
+}
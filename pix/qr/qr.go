@@ -0,0 +1,18 @@
+// Package qr renders Pix QR codes locally. It is kept out of the mercadopago
+// package so that only programs rendering QR codes depend on a QR code
+// encoder.
+package qr
+
+import (
+	"fmt"
+	"github.com/iurybraun/go-mercadopago-sdk"
+	"github.com/skip2/go-qrcode"
+)
+
+// PNG renders the QR code of a Pix payment as a size by size PNG image.
+func PNG(pix mercadopago.PixPayment, size int) ([]byte, error) {
+	if pix.Qr_code == "" {
+		return nil, fmt.Errorf("payment has no pix qr code")
+	}
+	return qrcode.Encode(pix.Qr_code, qrcode.Medium, size)
+}
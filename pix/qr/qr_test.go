@@ -0,0 +1,30 @@
+package qr
+
+import (
+    "bytes"
+    "github.com/iurybraun/go-mercadopago-sdk"
+    "github.com/stretchr/testify/require"
+    "image/png"
+    "testing"
+)
+
+const _pixQRCode = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestPNG(t *testing.T) {
+    // When
+    rendered, err := PNG(mercadopago.PixPayment{Qr_code: _pixQRCode}, 256)
+    require.NoError(t, err)
+
+    // Then
+    img, err := png.Decode(bytes.NewReader(rendered))
+    require.NoError(t, err)
+    require.Equal(t, 256, img.Bounds().Dx())
+}
+
+func TestPNG_Missing(t *testing.T) {
+    // When
+    _, err := PNG(mercadopago.PixPayment{}, 256)
+
+    // Then
+    require.EqualError(t, err, "payment has no pix qr code")
+}
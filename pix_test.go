@@ -0,0 +1,106 @@
+package mercadopago
+
+import (
+    "bytes"
+    "encoding/base64"
+    "encoding/json"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+    "time"
+)
+
+const _pixQRCode = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestGateway_CreatePixPayment(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "pending", "status_detail": "pending_waiting_transfer", "payment_method_id": "pix", "date_of_expiration": "2022-11-18T09:37:52.000-04:00", "point_of_interaction": {"type": "PIX", "transaction_data": {"qr_code": "` + _pixQRCode + `", "qr_code_base64": "iVBORw0KGgo=", "ticket_url": "https://www.mercadopago.com.br/payments/123/ticket"}}}`))),
+    }
+    // When
+    pix, err := g.CreatePixPayment("MY_ACCESS_TOKEN", NewPixPayment{
+        Transaction_amount: 100,
+        Description:        "Pro plan",
+        Payer:              PaymentPayer{Email: "test_user@testuser.com"},
+        Expires_in:         time.Hour,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 123, pix.Payment.Id)
+    require.Equal(t, PaymentStatusPending, pix.Payment.Status)
+    require.Equal(t, _pixQRCode, pix.Qr_code)
+    require.Equal(t, "iVBORw0KGgo=", pix.Qr_code_base64)
+    require.Equal(t, "https://www.mercadopago.com.br/payments/123/ticket", pix.Ticket_url)
+    require.Equal(t, time.Date(2022, 11, 18, 13, 37, 52, 0, time.UTC), pix.Expiration.UTC())
+
+    var body map[string]interface{}
+    require.NoError(t, json.NewDecoder(c.req.Body).Decode(&body))
+    require.Equal(t, "pix", body["payment_method_id"])
+    require.Equal(t, float64(100), body["transaction_amount"])
+
+    expiration, err := time.Parse(_dateLayout, body["date_of_expiration"].(string))
+    require.NoError(t, err)
+    require.WithinDuration(t, time.Now().Add(time.Hour), expiration, time.Minute)
+}
+
+func TestGateway_CreatePixPayment_DefaultExpiration(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "pending"}`))),
+    }
+    // When
+    pix, err := g.CreatePixPayment("MY_ACCESS_TOKEN", NewPixPayment{Transaction_amount: 10, Payer: PaymentPayer{Email: "test_user@testuser.com"}})
+
+    // Then
+    require.NoError(t, err)
+    require.True(t, pix.Expiration.IsZero())
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"transaction_amount": 10, "payment_method_id": "pix", "payer": {"email": "test_user@testuser.com"}}`, string(b))
+}
+
+func TestGateway_CreatePixPayment_InvalidExpiration(t *testing.T) {
+    tt := []time.Duration{time.Minute, 31 * 24 * time.Hour}
+
+    for _, expiresIn := range tt {
+        t.Run(expiresIn.String(), func(t *testing.T) {
+            // Given
+            c := &ClientStub{}
+            g := &Gateway{Client: c}
+
+            // When
+            _, err := g.CreatePixPayment("MY_ACCESS_TOKEN", NewPixPayment{Transaction_amount: 10, Expires_in: expiresIn})
+
+            // Then
+            require.Error(t, err)
+            require.Contains(t, err.Error(), "pix expiration must be between 30m0s and 720h0m0s")
+            require.Nil(t, c.req)
+        })
+    }
+}
+
+func TestPixPayment_QRCode(t *testing.T) {
+    // Given
+    pix := PixPayment{
+        Qr_code:        _pixQRCode,
+        Qr_code_base64: base64.StdEncoding.EncodeToString([]byte("PNG")),
+    }
+
+    // When
+    image, err := pix.QRCodeImage()
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, []byte("PNG"), image)
+}
@@ -0,0 +1,130 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Preapproval plan statuses.
+const (
+	PreapprovalPlanStatusActive    = "active"
+	PreapprovalPlanStatusCancelled = "cancelled"
+)
+
+// PreapprovalPlan is a subscription plan, a template of the billing of the
+// subscriptions created from it.
+type PreapprovalPlan struct {
+	ID                    string                `json:"id"`
+	Status                string                `json:"status"`
+	Reason                string                `json:"reason"`
+	ExternalReference     string                `json:"external_reference"`
+	CollectorID           int64                 `json:"collector_id"`
+	ApplicationID         int64                 `json:"application_id"`
+	BackURL               string                `json:"back_url"`
+	InitPoint             string                `json:"init_point"`
+	AutoRecurring         AutoRecurring         `json:"auto_recurring"`
+	PaymentMethodsAllowed PaymentMethodsAllowed `json:"payment_methods_allowed"`
+	DateCreated           string                `json:"date_created"`
+	LastModified          string                `json:"last_modified"`
+}
+
+// PaymentMethodsAllowed restricts the payment types (e.g. credit_card) and
+// methods (e.g. visa) subscribers of a plan may pay with.
+type PaymentMethodsAllowed struct {
+	PaymentTypes   []PaymentMethodsAllowedID `json:"payment_types,omitempty"`
+	PaymentMethods []PaymentMethodsAllowedID `json:"payment_methods,omitempty"`
+}
+
+type PaymentMethodsAllowedID struct {
+	ID string `json:"id"`
+}
+
+// NewPreapprovalPlan is the body of CreatePreapprovalPlan.
+type NewPreapprovalPlan struct {
+	Reason                string                 `json:"reason"`
+	ExternalReference     string                 `json:"external_reference,omitempty"`
+	AutoRecurring         AutoRecurring          `json:"auto_recurring"`
+	PaymentMethodsAllowed *PaymentMethodsAllowed `json:"payment_methods_allowed,omitempty"`
+	BackURL               string                 `json:"back_url"`
+}
+
+// PreapprovalPlanUpdate is the body of UpdatePreapprovalPlan; only the fields
+// set are changed. Changes apply to the subscriptions of the plan too.
+type PreapprovalPlanUpdate struct {
+	Reason                string                 `json:"reason,omitempty"`
+	ExternalReference     string                 `json:"external_reference,omitempty"`
+	AutoRecurring         *AutoRecurring         `json:"auto_recurring,omitempty"`
+	PaymentMethodsAllowed *PaymentMethodsAllowed `json:"payment_methods_allowed,omitempty"`
+	BackURL               string                 `json:"back_url,omitempty"`
+	Status                string                 `json:"status,omitempty"`
+}
+
+// PreapprovalPlanSearch filters a plans search. Empty fields are left out of
+// the query.
+type PreapprovalPlanSearch struct {
+	Status string
+	// Q matches the reason and external reference of the plans.
+	Q      string
+	Limit  int
+	Offset int
+}
+
+func (s PreapprovalPlanSearch) query() string {
+	q := url.Values{}
+	if s.Status != "" {
+		q.Set("status", s.Status)
+	}
+	if s.Q != "" {
+		q.Set("q", s.Q)
+	}
+	if s.Limit > 0 {
+		q.Set("limit", strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		q.Set("offset", strconv.Itoa(s.Offset))
+	}
+	return q.Encode()
+}
+
+type PreapprovalPlanSearchResponse struct {
+	Paging  SubscriptionPaging `json:"paging"`
+	Results []PreapprovalPlan  `json:"results"`
+}
+
+func (g *Gateway) CreatePreapprovalPlan(accessToken string, plan NewPreapprovalPlan) (PreapprovalPlan, error) {
+	return g.CreatePreapprovalPlanWithContext(context.Background(), accessToken, plan)
+}
+
+func (g *Gateway) CreatePreapprovalPlanWithContext(ctx context.Context, accessToken string, plan NewPreapprovalPlan) (created PreapprovalPlan, err error) {
+	err = g.doJSON(ctx, http.MethodPost, "/preapproval_plan", accessToken, plan, &created)
+	return
+}
+
+func (g *Gateway) GetPreapprovalPlan(accessToken string, planID string) (PreapprovalPlan, error) {
+	return g.GetPreapprovalPlanWithContext(context.Background(), accessToken, planID)
+}
+
+func (g *Gateway) GetPreapprovalPlanWithContext(ctx context.Context, accessToken string, planID string) (plan PreapprovalPlan, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/preapproval_plan/"+planID, accessToken, nil, &plan)
+	return
+}
+
+func (g *Gateway) UpdatePreapprovalPlan(accessToken string, planID string, update PreapprovalPlanUpdate) (PreapprovalPlan, error) {
+	return g.UpdatePreapprovalPlanWithContext(context.Background(), accessToken, planID, update)
+}
+
+func (g *Gateway) UpdatePreapprovalPlanWithContext(ctx context.Context, accessToken string, planID string, update PreapprovalPlanUpdate) (updated PreapprovalPlan, err error) {
+	err = g.doJSON(ctx, http.MethodPut, "/preapproval_plan/"+planID, accessToken, update, &updated)
+	return
+}
+
+func (g *Gateway) SearchPreapprovalPlans(accessToken string, search PreapprovalPlanSearch) (PreapprovalPlanSearchResponse, error) {
+	return g.SearchPreapprovalPlansWithContext(context.Background(), accessToken, search)
+}
+
+func (g *Gateway) SearchPreapprovalPlansWithContext(ctx context.Context, accessToken string, search PreapprovalPlanSearch) (plans PreapprovalPlanSearchResponse, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/preapproval_plan/search?"+search.query(), accessToken, nil, &plans)
+	return
+}
@@ -0,0 +1,121 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreatePreapprovalPlan(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "PLAN_ID", "status": "active", "reason": "Pro", "init_point": "https://www.mercadopago.com.br/subscriptions/checkout?preapproval_plan_id=PLAN_ID", "auto_recurring": {"frequency": 1, "frequency_type": "months", "repetitions": 12, "billing_day": 10, "billing_day_proportional": true, "free_trial": {"frequency": 7, "frequency_type": "days"}, "transaction_amount": 49.9, "currency_id": "BRL"}, "payment_methods_allowed": {"payment_types": [{"id": "credit_card"}]}}`))),
+    }
+    // When
+    plan, err := g.CreatePreapprovalPlan("MY_ACCESS_TOKEN", NewPreapprovalPlan{
+        Reason:  "Pro",
+        BackURL: "https://example.com/back",
+        AutoRecurring: AutoRecurring{
+            Frequency:              1,
+            FrequencyType:          "months",
+            Repetitions:            12,
+            BillingDay:             10,
+            BillingDayProportional: true,
+            FreeTrial:              &FreeTrial{Frequency: 7, FrequencyType: "days"},
+            TransactionAmount:      49.9,
+            CurrencyID:             "BRL",
+        },
+        PaymentMethodsAllowed: &PaymentMethodsAllowed{
+            PaymentTypes: []PaymentMethodsAllowedID{{ID: "credit_card"}},
+        },
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "PLAN_ID", plan.ID)
+    require.Equal(t, PreapprovalPlanStatusActive, plan.Status)
+    require.Equal(t, 12, plan.AutoRecurring.Repetitions)
+    require.Equal(t, 10, plan.AutoRecurring.BillingDay)
+    require.True(t, plan.AutoRecurring.BillingDayProportional)
+    require.Equal(t, &FreeTrial{Frequency: 7, FrequencyType: "days"}, plan.AutoRecurring.FreeTrial)
+    require.Equal(t, "credit_card", plan.PaymentMethodsAllowed.PaymentTypes[0].ID)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/preapproval_plan", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"reason": "Pro", "back_url": "https://example.com/back", "auto_recurring": {"frequency": 1, "frequency_type": "months", "repetitions": 12, "billing_day": 10, "billing_day_proportional": true, "free_trial": {"frequency": 7, "frequency_type": "days"}, "transaction_amount": 49.9, "currency_id": "BRL"}, "payment_methods_allowed": {"payment_types": [{"id": "credit_card"}]}}`, string(b))
+}
+
+func TestGateway_GetPreapprovalPlan(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "PLAN_ID", "status": "active", "reason": "Pro"}`))),
+    }
+    // When
+    plan, err := g.GetPreapprovalPlan("MY_ACCESS_TOKEN", "PLAN_ID")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "Pro", plan.Reason)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/preapproval_plan/PLAN_ID", c.req.URL.Path)
+}
+
+func TestGateway_UpdatePreapprovalPlan(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "PLAN_ID", "status": "active", "auto_recurring": {"transaction_amount": 59.9, "currency_id": "BRL"}}`))),
+    }
+    // When
+    plan, err := g.UpdatePreapprovalPlan("MY_ACCESS_TOKEN", "PLAN_ID", PreapprovalPlanUpdate{
+        AutoRecurring: &AutoRecurring{TransactionAmount: 59.9, CurrencyID: "BRL"},
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 59.9, plan.AutoRecurring.TransactionAmount)
+    require.Equal(t, http.MethodPut, c.req.Method)
+    require.Equal(t, "/preapproval_plan/PLAN_ID", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"auto_recurring": {"transaction_amount": 59.9, "currency_id": "BRL"}}`, string(b))
+}
+
+func TestGateway_SearchPreapprovalPlans(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"offset": 0, "limit": 20, "total": 2}, "results": [{"id": "PLAN_1"}, {"id": "PLAN_2"}]}`))),
+    }
+    // When
+    plans, err := g.SearchPreapprovalPlans("MY_ACCESS_TOKEN", PreapprovalPlanSearch{
+        Status: PreapprovalPlanStatusActive,
+        Limit:  20,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 2, plans.Paging.Total)
+    require.Len(t, plans.Results, 2)
+    require.Equal(t, "/preapproval_plan/search", c.req.URL.Path)
+    require.Equal(t, "limit=20&status=active", c.req.URL.RawQuery)
+}
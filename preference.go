@@ -0,0 +1,163 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Preference is the checkout preference resource returned by
+// /checkout/preferences. Its Init_point is the Checkout Pro URL to send the
+// payer to.
+type Preference struct {
+	Id                   string                 `json:"id"`
+	Client_id            string                 `json:"client_id"`
+	Collector_id         int64                  `json:"collector_id"`
+	External_reference   string                 `json:"external_reference"`
+	Items                []Item                 `json:"items"`
+	Payer                Payer                  `json:"payer"`
+	Payment_methods      Payment_methods        `json:"payment_methods"`
+	Back_urls            Back_urls              `json:"back_urls"`
+	Auto_return          string                 `json:"auto_return"`
+	Notification_url     string                 `json:"notification_url"`
+	Additional_info      string                 `json:"additional_info"`
+	Marketplace          string                 `json:"marketplace"`
+	Marketplace_fee      float64                `json:"marketplace_fee"`
+	Operation_type       string                 `json:"operation_type"`
+	Site_id              string                 `json:"site_id"`
+	Sponsor_id           int64                  `json:"sponsor_id"`
+	Shipments            Shipments              `json:"shipments"`
+	Binary_mode          bool                   `json:"binary_mode"`
+	Statement_descriptor string                 `json:"statement_descriptor"`
+	Purpose              string                 `json:"purpose"`
+	Processing_modes     []string               `json:"processing_modes"`
+	Expires              bool                   `json:"expires"`
+	Expiration_date_from string                 `json:"expiration_date_from"`
+	Expiration_date_to   string                 `json:"expiration_date_to"`
+	Init_point           string                 `json:"init_point"`
+	Sandbox_init_point   string                 `json:"sandbox_init_point"`
+	Metadata             map[string]interface{} `json:"metadata"`
+	Date_created         string                 `json:"date_created"`
+	Last_updated         string                 `json:"last_updated"`
+}
+
+// Total returns the amount of the preference, the sum of its items.
+func (p Preference) Total() float64 {
+	var total float64
+	for _, i := range p.Items {
+		total += i.UnitPrice * float64(i.Quantity)
+	}
+	return total
+}
+
+// PreferenceUpdate is the body of UpdatePreference; only the fields set are
+// changed.
+type PreferenceUpdate struct {
+	External_reference   string           `json:"external_reference,omitempty"`
+	Items                []Item           `json:"items,omitempty"`
+	Payer                *Payer           `json:"payer,omitempty"`
+	Payment_methods      *Payment_methods `json:"payment_methods,omitempty"`
+	Back_urls            *Back_urls       `json:"back_urls,omitempty"`
+	Auto_return          string           `json:"auto_return,omitempty"`
+	Notification_url     string           `json:"notification_url,omitempty"`
+	Additional_info      string           `json:"additional_info,omitempty"`
+	Expires              *bool            `json:"expires,omitempty"`
+	Expiration_date_from string           `json:"expiration_date_from,omitempty"`
+	Expiration_date_to   string           `json:"expiration_date_to,omitempty"`
+}
+
+// PreferenceSearch filters a preferences search. Empty fields are left out of
+// the query.
+type PreferenceSearch struct {
+	External_reference string
+	Site_id            string
+	Marketplace        string
+	Sponsor_id         int64
+	Collector_id       int64
+	Limit              int
+	Offset             int
+}
+
+func (s PreferenceSearch) query() string {
+	q := url.Values{}
+	if s.External_reference != "" {
+		q.Set("external_reference", s.External_reference)
+	}
+	if s.Site_id != "" {
+		q.Set("site_id", s.Site_id)
+	}
+	if s.Marketplace != "" {
+		q.Set("marketplace", s.Marketplace)
+	}
+	if s.Sponsor_id != 0 {
+		q.Set("sponsor_id", strconv.FormatInt(s.Sponsor_id, 10))
+	}
+	if s.Collector_id != 0 {
+		q.Set("collector_id", strconv.FormatInt(s.Collector_id, 10))
+	}
+	if s.Limit > 0 {
+		q.Set("limit", strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		q.Set("offset", strconv.Itoa(s.Offset))
+	}
+	return q.Encode()
+}
+
+// PreferenceSummary is a preference as listed by /checkout/preferences/search,
+// where items are only their titles.
+type PreferenceSummary struct {
+	Id                   string   `json:"id"`
+	Client_id            string   `json:"client_id"`
+	Collector_id         int64    `json:"collector_id"`
+	External_reference   string   `json:"external_reference"`
+	Items                []string `json:"items"`
+	Payer_email          string   `json:"payer_email"`
+	Payer_id             int64    `json:"payer_id"`
+	Marketplace          string   `json:"marketplace"`
+	Operation_type       string   `json:"operation_type"`
+	Site_id              string   `json:"site_id"`
+	Sponsor_id           int64    `json:"sponsor_id"`
+	Live_mode            bool     `json:"live_mode"`
+	Expires              bool     `json:"expires"`
+	Expiration_date_from string   `json:"expiration_date_from"`
+	Expiration_date_to   string   `json:"expiration_date_to"`
+	Date_created         string   `json:"date_created"`
+	Last_updated         string   `json:"last_updated"`
+}
+
+// PreferenceSearchResponse is a page of preferences returned by
+// /checkout/preferences/search.
+type PreferenceSearchResponse struct {
+	Elements    []PreferenceSummary `json:"elements"`
+	Next_offset int                 `json:"next_offset"`
+	Total       int                 `json:"total"`
+}
+
+func (g *Gateway) GetPreference(accessToken string, id string) (Preference, error) {
+	return g.GetPreferenceWithContext(context.Background(), accessToken, id)
+}
+
+func (g *Gateway) GetPreferenceWithContext(ctx context.Context, accessToken string, id string) (preference Preference, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/checkout/preferences/"+id, accessToken, nil, &preference)
+	return
+}
+
+func (g *Gateway) UpdatePreference(accessToken string, id string, update PreferenceUpdate) (Preference, error) {
+	return g.UpdatePreferenceWithContext(context.Background(), accessToken, id, update)
+}
+
+func (g *Gateway) UpdatePreferenceWithContext(ctx context.Context, accessToken string, id string, update PreferenceUpdate) (updated Preference, err error) {
+	err = g.doJSON(ctx, http.MethodPut, "/checkout/preferences/"+id, accessToken, update, &updated)
+	return
+}
+
+func (g *Gateway) SearchPreferences(accessToken string, search PreferenceSearch) (PreferenceSearchResponse, error) {
+	return g.SearchPreferencesWithContext(context.Background(), accessToken, search)
+}
+
+func (g *Gateway) SearchPreferencesWithContext(ctx context.Context, accessToken string, search PreferenceSearch) (preferences PreferenceSearchResponse, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/checkout/preferences/search?"+search.query(), accessToken, nil, &preferences)
+	return
+}
@@ -0,0 +1,223 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreatePreference_Body(t *testing.T) {
+    tt := []struct{
+        name string
+        preference NewPreference
+        wantBody string
+    }{
+        {
+            name: "unset fields are left out",
+            preference: NewPreference{
+                Items: []Item{{Title: "Pro plan", Quantity: 1, UnitPrice: 10.5}},
+                Payer: Payer{Email: "test_user@testuser.com"},
+            },
+            wantBody: `{
+                "items": [{"title": "Pro plan", "quantity": 1, "unit_price": 10.5}],
+                "payer": {"email": "test_user@testuser.com"}
+            }`,
+        },
+        {
+            name: "every field",
+            preference: NewPreference{
+                External_reference: "ORDER_1",
+                Items:              []Item{{Id: "1", Title: "Pro plan", Currency_id: "BRL", Quantity: 1, UnitPrice: 10.5}},
+                Payer: Payer{
+                    Email:          "test_user@testuser.com",
+                    Phone:          Phone{Area_code: "11", Number: "987654321"},
+                    Identification: Identification{Type: "CPF", Number: "19119119100"},
+                    Address:        Address{Zip_code: "06233200", Street_name: "Av. das Nacoes Unidas", Street_number: 3003},
+                },
+                Payment_methods: Payment_methods{
+                    Excluded_payment_types: []Excluded_payment_types{{Id: "ticket"}},
+                    Installments:           6,
+                    Default_installments:   3,
+                },
+                Back_urls:  Back_urls{Success: "https://example.com/success"},
+                AutoReturn: AutoReturnApproved,
+                Shipments: &Shipments{
+                    Mode:             ShippingModeCustom,
+                    Cost:             15,
+                    Receiver_address: &ReceiverAddress{Zip_code: "06233200"},
+                },
+                Expires:              true,
+                Expiration_date_from: "2030-01-01T00:00:00.000-03:00",
+                Expiration_date_to:   "2030-01-10T00:00:00.000-03:00",
+                Binary_mode:          true,
+                Statement_descriptor: "MYSTORE",
+                Metadata:             map[string]interface{}{"order_id": "1"},
+                Marketplace:          "MP-MKT-123",
+                Marketplace_fee:      1.5,
+                Differential_pricing: &Differential_pricing{Id: 7},
+                Tracks:               []Track{{Type: TrackTypeFacebookAd, Values: map[string]interface{}{"pixel_id": "PIXEL_ID"}}},
+                Purpose:              PurposeWalletPurchase,
+                Processing_modes:     []string{ProcessingModeAggregator},
+            },
+            wantBody: `{
+                "external_reference": "ORDER_1",
+                "items": [{"id": "1", "title": "Pro plan", "currency_id": "BRL", "quantity": 1, "unit_price": 10.5}],
+                "payer": {
+                    "email": "test_user@testuser.com",
+                    "phone": {"area_code": "11", "number": "987654321"},
+                    "identification": {"type": "CPF", "number": "19119119100"},
+                    "address": {"zip_code": "06233200", "street_name": "Av. das Nacoes Unidas", "street_number": 3003}
+                },
+                "payment_methods": {"excluded_payment_types": [{"id": "ticket"}], "installments": 6, "default_installments": 3},
+                "back_urls": {"success": "https://example.com/success"},
+                "auto_return": "approved",
+                "shipments": {"mode": "custom", "cost": 15, "receiver_address": {"zip_code": "06233200"}},
+                "expires": true,
+                "expiration_date_from": "2030-01-01T00:00:00.000-03:00",
+                "expiration_date_to": "2030-01-10T00:00:00.000-03:00",
+                "binary_mode": true,
+                "statement_descriptor": "MYSTORE",
+                "metadata": {"order_id": "1"},
+                "marketplace": "MP-MKT-123",
+                "marketplace_fee": 1.5,
+                "differential_pricing": {"id": 7},
+                "tracks": [{"type": "facebook_ad", "values": {"pixel_id": "PIXEL_ID"}}],
+                "purpose": "wallet_purchase",
+                "processing_modes": ["aggregator"]
+            }`,
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // Given
+            c := &ClientStub{}
+            g := &Gateway{Client: c}
+            c.resp = &http.Response{
+                Status:     "201",
+                StatusCode: 201,
+                Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "123-abc", "init_point": "https://www.mercadopago.com.br/checkout/v1/redirect?pref_id=123-abc"}`))),
+            }
+
+            // When
+            _, err := g.CreatePreference("MY_ACCESS_TOKEN", tc.preference)
+
+            // Then
+            require.NoError(t, err)
+            b, err := ioutil.ReadAll(c.req.Body)
+            require.NoError(t, err)
+            require.JSONEq(t, tc.wantBody, string(b))
+        })
+    }
+}
+
+func TestGateway_GetPreference(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "123-abc", "collector_id": 98765, "external_reference": "ORDER_1", "items": [{"id": "1", "title": "Pro plan", "quantity": 2, "unit_price": 10.25, "currency_id": "BRL"}], "payer": {"email": "test_user@testuser.com"}, "back_urls": {"success": "https://example.com/success"}, "expires": true, "expiration_date_to": "2030-01-10T22:59:59.000-04:00", "init_point": "https://www.mercadopago.com.br/checkout/v1/redirect?pref_id=123-abc", "sandbox_init_point": "https://sandbox.mercadopago.com.br/checkout/v1/redirect?pref_id=123-abc", "date_created": "2029-12-10T10:00:00.000-04:00"}`))),
+    }
+    // When
+    preference, err := g.GetPreference("MY_ACCESS_TOKEN", "123-abc")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "123-abc", preference.Id)
+    require.Equal(t, int64(98765), preference.Collector_id)
+    require.Len(t, preference.Items, 1)
+    require.Equal(t, "Pro plan", preference.Items[0].Title)
+    require.Equal(t, 20.5, preference.Total())
+    require.Equal(t, "test_user@testuser.com", preference.Payer.Email)
+    require.Equal(t, "https://example.com/success", preference.Back_urls.Success)
+    require.True(t, preference.Expires)
+    require.Equal(t, "2030-01-10T22:59:59.000-04:00", preference.Expiration_date_to)
+    require.Equal(t, "https://www.mercadopago.com.br/checkout/v1/redirect?pref_id=123-abc", preference.Init_point)
+    require.Equal(t, "https://sandbox.mercadopago.com.br/checkout/v1/redirect?pref_id=123-abc", preference.Sandbox_init_point)
+    require.Equal(t, "2029-12-10T10:00:00.000-04:00", preference.Date_created)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/checkout/preferences/123-abc", c.req.URL.Path)
+    require.Equal(t, "Bearer MY_ACCESS_TOKEN", c.req.Header.Get("Authorization"))
+}
+
+func TestGateway_GetCheckoutPreferences(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "123-abc", "items": [{"title": "Pro plan", "quantity": 2, "unit_price": 10.25}]}`))),
+    }
+    // When
+    total, err := g.GetCheckoutPreferences("MY_ACCESS_TOKEN", "123-abc")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 20, total)
+}
+
+func TestGateway_UpdatePreference(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "123-abc", "external_reference": "ORDER_2", "expires": false}`))),
+    }
+    expires := false
+
+    // When
+    preference, err := g.UpdatePreference("MY_ACCESS_TOKEN", "123-abc", PreferenceUpdate{
+        External_reference: "ORDER_2",
+        Expires:            &expires,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "ORDER_2", preference.External_reference)
+    require.Equal(t, http.MethodPut, c.req.Method)
+    require.Equal(t, "/checkout/preferences/123-abc", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"external_reference": "ORDER_2", "expires": false}`, string(b))
+}
+
+func TestGateway_SearchPreferences(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"elements": [{"id": "123-abc", "external_reference": "ORDER 1/2", "items": ["Pro plan"], "payer_email": "test_user@testuser.com"}], "next_offset": 11, "total": 25}`))),
+    }
+    // When
+    preferences, err := g.SearchPreferences("MY_ACCESS_TOKEN", PreferenceSearch{
+        External_reference: "ORDER 1/2",
+        Sponsor_id:         12345,
+        Limit:              10,
+        Offset:             1,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Len(t, preferences.Elements, 1)
+    require.Equal(t, "123-abc", preferences.Elements[0].Id)
+    require.Equal(t, []string{"Pro plan"}, preferences.Elements[0].Items)
+    require.Equal(t, 11, preferences.Next_offset)
+    require.Equal(t, 25, preferences.Total)
+    require.Equal(t, http.MethodGet, c.req.Method)
+    require.Equal(t, "/checkout/preferences/search", c.req.URL.Path)
+    require.Equal(t, "ORDER 1/2", c.req.URL.Query().Get("external_reference"))
+    require.Equal(t, "12345", c.req.URL.Query().Get("sponsor_id"))
+    require.Equal(t, "10", c.req.URL.Query().Get("limit"))
+    require.Equal(t, "1", c.req.URL.Query().Get("offset"))
+    require.Empty(t, c.req.URL.Query().Get("site_id"))
+}
@@ -0,0 +1,94 @@
+package mercadopago
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusApproved   RefundStatus = "approved"
+	RefundStatusInProcess  RefundStatus = "in_process"
+	RefundStatusRejected   RefundStatus = "rejected"
+	RefundStatusCancelled  RefundStatus = "cancelled"
+	RefundStatusAuthorized RefundStatus = "authorized"
+)
+
+// Refund source types, telling who originated a refund.
+const (
+	RefundSourceCollector = "collector"
+	RefundSourceAdmin     = "admin"
+	RefundSourceBPP       = "bpp"
+)
+
+type RefundSource struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Refund is the refund resource returned by /v1/payments/{id}/refunds.
+type Refund struct {
+	Id                       int                    `json:"id"`
+	Payment_id               int                    `json:"payment_id"`
+	Amount                   float64                `json:"amount"`
+	Amount_refunded_to_payer float64                `json:"amount_refunded_to_payer"`
+	Adjustment_amount        float64                `json:"adjustment_amount"`
+	Status                   RefundStatus           `json:"status"`
+	Refund_mode              string                 `json:"refund_mode"`
+	Reason                   string                 `json:"reason"`
+	Source                   RefundSource           `json:"source"`
+	Unique_sequence_number   string                 `json:"unique_sequence_number"`
+	Date_created             string                 `json:"date_created"`
+	Metadata                 map[string]interface{} `json:"metadata"`
+}
+
+// CreateRefund refunds amount of the given payment, which must be a positive
+// number. Use CreateFullRefund to refund the whole payment.
+func (g *Gateway) CreateRefund(accessToken string, paymentID string, amount float64) (Refund, error) {
+	return g.CreateRefundWithContext(context.Background(), accessToken, paymentID, amount)
+}
+
+func (g *Gateway) CreateRefundWithContext(ctx context.Context, accessToken string, paymentID string, amount float64) (refund Refund, err error) {
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Refund{}, fmt.Errorf("refund amount must be a positive number, got %v", amount)
+	}
+
+	body := struct {
+		Amount float64 `json:"amount"`
+	}{amount}
+
+	err = g.doJSON(ctx, http.MethodPost, "/v1/payments/"+paymentID+"/refunds", accessToken, body, &refund)
+	return
+}
+
+// CreateFullRefund refunds the whole amount of the given payment.
+func (g *Gateway) CreateFullRefund(accessToken string, paymentID string) (Refund, error) {
+	return g.CreateFullRefundWithContext(context.Background(), accessToken, paymentID)
+}
+
+func (g *Gateway) CreateFullRefundWithContext(ctx context.Context, accessToken string, paymentID string) (refund Refund, err error) {
+	err = g.doJSON(ctx, http.MethodPost, "/v1/payments/"+paymentID+"/refunds", accessToken, struct{}{}, &refund)
+	return
+}
+
+func (g *Gateway) GetRefund(accessToken string, paymentID string, refundID string) (Refund, error) {
+	return g.GetRefundWithContext(context.Background(), accessToken, paymentID, refundID)
+}
+
+func (g *Gateway) GetRefundWithContext(ctx context.Context, accessToken string, paymentID string, refundID string) (refund Refund, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/payments/"+paymentID+"/refunds/"+refundID, accessToken, nil, &refund)
+	return
+}
+
+func (g *Gateway) GetRefunds(accessToken string, paymentID string) ([]Refund, error) {
+	return g.GetRefundsWithContext(context.Background(), accessToken, paymentID)
+}
+
+func (g *Gateway) GetRefundsWithContext(ctx context.Context, accessToken string, paymentID string) (refunds []Refund, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/v1/payments/"+paymentID+"/refunds", accessToken, nil, &refunds)
+	return
+}
@@ -0,0 +1,191 @@
+package mercadopago
+
+import (
+    "bytes"
+    "errors"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "math"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreateFullRefund(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 1, "payment_id": 123, "amount": 15.75, "status": "approved", "source": {"id": "1", "name": "seller", "type": "collector"}}`))),
+    }
+    // When
+    refund, err := g.CreateFullRefund("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 15.75, refund.Amount)
+    require.Equal(t, RefundStatusApproved, refund.Status)
+    require.Equal(t, RefundSourceCollector, refund.Source.Type)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/v1/payments/123/refunds", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{}`, string(b))
+}
+
+func TestGateway_CreateRefund_Partial(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 1, "payment_id": 123, "amount": 5, "status": "approved"}`))),
+    }
+    // When
+    refund, err := g.CreateRefund("MY_ACCESS_TOKEN", "123", 5)
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, float64(5), refund.Amount)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"amount": 5}`, string(b))
+}
+
+func TestGateway_CreateRefund_InvalidAmount(t *testing.T) {
+    tt := []struct{
+        name string
+        amount float64
+        wantError string
+    }{
+        {
+            name: "zero",
+            amount: 0,
+            wantError: "refund amount must be a positive number, got 0",
+        },
+        {
+            name: "negative",
+            amount: -5,
+            wantError: "refund amount must be a positive number, got -5",
+        },
+        {
+            name: "NaN",
+            amount: math.NaN(),
+            wantError: "refund amount must be a positive number, got NaN",
+        },
+        {
+            name: "positive infinity",
+            amount: math.Inf(1),
+            wantError: "refund amount must be a positive number, got +Inf",
+        },
+        {
+            name: "negative infinity",
+            amount: math.Inf(-1),
+            wantError: "refund amount must be a positive number, got -Inf",
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // Given
+            c := &ClientStub{}
+            g := &Gateway{Client: c}
+
+            // When
+            _, err := g.CreateRefund("MY_ACCESS_TOKEN", "123", tc.amount)
+
+            // Then
+            require.EqualError(t, err, tc.wantError)
+            require.Nil(t, c.req)
+        })
+    }
+}
+
+func TestGateway_CreateRefund_MercadoPagoError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "404",
+        StatusCode: 404,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message": "Payment not found"}`))),
+    }
+    // When
+    _, err := g.CreateRefund("MY_ACCESS_TOKEN", "123", 10)
+
+    // Then
+    require.Error(t, err)
+    require.Equal(t, http.StatusNotFound, getStatusCodeFromError(err))
+}
+
+func TestGateway_CreateRefund_DoError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.err = errors.New("do error")
+    // When
+    _, err := g.CreateRefund("MY_ACCESS_TOKEN", "123", 10)
+
+    // Then
+    require.Error(t, err)
+    require.EqualError(t, err, "do error")
+}
+
+func TestGateway_GetRefund(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 7, "payment_id": 123, "amount": 5, "status": "in_process"}`))),
+    }
+    // When
+    refund, err := g.GetRefund("MY_ACCESS_TOKEN", "123", "7")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 7, refund.Id)
+    require.Equal(t, RefundStatusInProcess, refund.Status)
+    require.Equal(t, "/v1/payments/123/refunds/7", c.req.URL.Path)
+}
+
+func TestGateway_GetRefunds(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id": 7, "amount": 5}, {"id": 8, "amount": 10.75}]`))),
+    }
+    // When
+    refunds, err := g.GetRefunds("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Len(t, refunds, 2)
+    require.Equal(t, 10.75, refunds[1].Amount)
+    require.Equal(t, http.MethodGet, c.req.Method)
+}
+
+func TestGateway_GetRefunds_UnmarshalError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 7}`))),
+    }
+    // When
+    _, err := g.GetRefunds("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.Error(t, err)
+    require.EqualError(t, err, "json: cannot unmarshal object into Go value of type []mercadopago.Refund")
+}
@@ -0,0 +1,200 @@
+package mercadopago
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	_defaultRetryAttempts  = 3
+	_defaultRetryBaseDelay = 200 * time.Millisecond
+	_defaultRetryMaxDelay  = 5 * time.Second
+)
+
+// RetryPolicy tells RetryClient how often and how long to retry. Zero values
+// fall back to 3 attempts and a backoff between 200ms and 5s.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, the first one included.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on every
+	// further retry up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return _defaultRetryAttempts
+	}
+	return p.MaxAttempts
+}
+
+func (p RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay <= 0 {
+		return _defaultRetryMaxDelay
+	}
+	return p.MaxDelay
+}
+
+// backoff returns the delay before the given retry (1 for the first one),
+// picked at random in the upper half of the exponential delay.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	base, max := p.BaseDelay, p.maxDelay()
+	if base <= 0 {
+		base = _defaultRetryBaseDelay
+	}
+
+	d := base
+	for i := 1; i < retry && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// RetryClient is a Client that retries transient failures - timeouts,
+// connection resets, 429 and 5xx responses - with exponential backoff and
+// jitter, honoring the Retry-After header up to the policy MaxDelay. Only idempotent requests are retried: GETs
+// and requests carrying an X-Idempotency-Key header.
+type RetryClient struct {
+	Client Client
+	Policy RetryPolicy
+}
+
+func NewRetryClient(client Client, policy RetryPolicy) *RetryClient {
+	return &RetryClient{
+		Client: client,
+		Policy: policy,
+	}
+}
+
+// WithRetry wraps the gateway client in a RetryClient.
+func WithRetry(policy RetryPolicy) GatewayOption {
+	return func(g *Gateway) {
+		g.Client = NewRetryClient(g.Client, policy)
+	}
+}
+
+func (c *RetryClient) Do(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req) || !canRewind(req) {
+		return c.Client.Do(req)
+	}
+
+	ctx := req.Context()
+	attempts := c.Policy.maxAttempts()
+
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := c.Client.Do(r)
+		if attempt >= attempts || !isTransient(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := c.Policy.backoff(attempt)
+		if err == nil {
+			if d, ok := retryAfter(resp); ok {
+				delay = d
+				if max := c.Policy.maxDelay(); delay > max {
+					delay = max
+				}
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return req.Header.Get("X-Idempotency-Key") != ""
+}
+
+// canRewind reports whether the request body can be sent again.
+func canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func isTransient(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return isTransientError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransientError reports whether a network error is worth retrying: a
+// timeout, a connection reset or a connection closed before the response was
+// read. Other errors, such as TLS or malformed URL ones, fail the same way on
+// every attempt.
+func isTransientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryAfter parses the Retry-After header, sent either as seconds or as an
+// HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,227 @@
+package mercadopago
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "github.com/stretchr/testify/require"
+    "io"
+    "io/ioutil"
+    "net/http"
+    "net/url"
+    "syscall"
+    "testing"
+    "time"
+)
+
+type SequenceClientStub struct {
+    resps  []*http.Response
+    errs   []error
+    bodies []string
+    reqs   []*http.Request
+    calls  int
+}
+
+func (c *SequenceClientStub) Do(req *http.Request) (*http.Response, error) {
+    i := c.calls
+    c.calls++
+    c.reqs = append(c.reqs, req)
+
+    if req.Body != nil {
+        b, _ := ioutil.ReadAll(req.Body)
+        c.bodies = append(c.bodies, string(b))
+    }
+
+    if i < len(c.errs) && c.errs[i] != nil {
+        return nil, c.errs[i]
+    }
+
+    return c.resps[i], nil
+}
+
+func newResponse(statusCode int, body string) *http.Response {
+    return &http.Response{
+        StatusCode: statusCode,
+        Header:     http.Header{},
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+    }
+}
+
+func TestRetryClient_RetriesTransientFailures(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            nil,
+            newResponse(http.StatusServiceUnavailable, `{"message": "unavailable"}`),
+            newResponse(http.StatusOK, `{"id": 123}`),
+        },
+        errs: []error{&url.Error{Op: "Get", URL: "https://api.mercadopago.com/v1/payments/123", Err: syscall.ECONNRESET}},
+    }
+    g := NewGateway(c, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+    // When
+    payment, err := g.GetPayments("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 123, payment.Id)
+    require.Equal(t, 3, c.calls)
+}
+
+func TestRetryClient_GivesUpAfterMaxAttempts(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusTooManyRequests, `{"message": "too many requests"}`),
+            newResponse(http.StatusTooManyRequests, `{"message": "too many requests"}`),
+        },
+    }
+    g := NewGateway(c, WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+
+    // When
+    _, err := g.GetPayments("MY_ACCESS_TOKEN", "123")
+
+    // Then
+    require.True(t, IsRateLimited(err))
+    require.Equal(t, 2, c.calls)
+}
+
+func TestRetryClient_DoesNotRetryNonIdempotentRequests(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusInternalServerError, `{"message": "internal server error"}`),
+        },
+    }
+    g := NewGateway(c, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+    // When
+    _, err := g.CreatePreference("MY_ACCESS_TOKEN", newPreference())
+
+    // Then
+    require.EqualError(t, err, "internal server error")
+    require.Equal(t, 1, c.calls)
+}
+
+func TestRetryClient_RewindsBody(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusBadGateway, ``),
+            newResponse(http.StatusOK, `{"id": "PREFERENCE_ID"}`),
+        },
+    }
+    r := NewRetryClient(c, RetryPolicy{BaseDelay: time.Millisecond})
+    req, err := http.NewRequest(http.MethodPost, "https://api.mercadopago.com/checkout/preferences", bytes.NewReader([]byte(`{"items": []}`)))
+    require.NoError(t, err)
+    req.Header.Set("X-Idempotency-Key", "KEY")
+
+    // When
+    resp, err := r.Do(req)
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, http.StatusOK, resp.StatusCode)
+    require.Equal(t, []string{`{"items": []}`, `{"items": []}`}, c.bodies)
+}
+
+func TestRetryClient_HonorsRetryAfter(t *testing.T) {
+    // Given
+    throttled := newResponse(http.StatusTooManyRequests, ``)
+    throttled.Header.Set("Retry-After", "1")
+    c := &SequenceClientStub{
+        resps: []*http.Response{throttled, newResponse(http.StatusOK, `{}`)},
+    }
+    r := NewRetryClient(c, RetryPolicy{BaseDelay: time.Millisecond})
+    ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+    defer cancel()
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.mercadopago.com/v1/payments/123", nil)
+    require.NoError(t, err)
+
+    // When
+    _, err = r.Do(req)
+
+    // Then
+    require.True(t, errors.Is(err, context.DeadlineExceeded))
+    require.Equal(t, 1, c.calls)
+}
+
+func TestRetryClient_CapsRetryAfter(t *testing.T) {
+    // Given
+    throttled := newResponse(http.StatusTooManyRequests, ``)
+    throttled.Header.Set("Retry-After", "3600")
+    c := &SequenceClientStub{
+        resps: []*http.Response{throttled, newResponse(http.StatusOK, `{}`)},
+    }
+    r := NewRetryClient(c, RetryPolicy{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.mercadopago.com/v1/payments/123", nil)
+    require.NoError(t, err)
+
+    // When
+    resp, err := r.Do(req)
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, http.StatusOK, resp.StatusCode)
+    require.Equal(t, 2, c.calls)
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTransientError(t *testing.T) {
+    tt := []struct{
+        name string
+        err error
+        want bool
+    }{
+        {
+            name: "timeout",
+            err: &url.Error{Op: "Get", URL: "https://api.mercadopago.com", Err: timeoutError{}},
+            want: true,
+        },
+        {
+            name: "connection reset",
+            err: &url.Error{Op: "Get", URL: "https://api.mercadopago.com", Err: syscall.ECONNRESET},
+            want: true,
+        },
+        {
+            name: "connection closed",
+            err: &url.Error{Op: "Get", URL: "https://api.mercadopago.com", Err: io.EOF},
+            want: true,
+        },
+        {
+            name: "tls error",
+            err: &url.Error{Op: "Get", URL: "https://api.mercadopago.com", Err: errors.New("tls: failed to verify certificate")},
+            want: false,
+        },
+        {
+            name: "unsupported scheme",
+            err: &url.Error{Op: "Get", URL: "htp://api.mercadopago.com", Err: errors.New("unsupported protocol scheme \"htp\"")},
+            want: false,
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            require.Equal(t, tc.want, isTransientError(tc.err))
+        })
+    }
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+    p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+    for i := 0; i < 100; i++ {
+        first := p.backoff(1)
+        require.True(t, first >= 50*time.Millisecond && first <= 100*time.Millisecond, first)
+
+        capped := p.backoff(5)
+        require.True(t, capped >= 150*time.Millisecond && capped <= 300*time.Millisecond, capped)
+    }
+}
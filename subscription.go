@@ -0,0 +1,216 @@
+package mercadopago
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Subscription (preapproval) statuses.
+const (
+	SubscriptionStatusPending    = "pending"
+	SubscriptionStatusAuthorized = "authorized"
+	SubscriptionStatusPaused     = "paused"
+	SubscriptionStatusCancelled  = "cancelled"
+)
+
+// NewSubscription is the body of CreateSubscription. Subscriptions without a
+// plan set Reason, AutoRecurring and BackURL; they are created pending, and
+// the payer authorizes them at the InitPoint of the result, unless a
+// CardTokenID is sent with the authorized status. Subscriptions with a plan
+// set PreapprovalPlanID and a CardTokenID and take the billing from the plan.
+type NewSubscription struct {
+	PreapprovalPlanID string         `json:"preapproval_plan_id,omitempty"`
+	Reason            string         `json:"reason,omitempty"`
+	ExternalReference string         `json:"external_reference,omitempty"`
+	PayerEmail        string         `json:"payer_email"`
+	CardTokenID       string         `json:"card_token_id,omitempty"`
+	AutoRecurring     *AutoRecurring `json:"auto_recurring,omitempty"`
+	BackURL           string         `json:"back_url,omitempty"`
+	Status            string         `json:"status,omitempty"`
+}
+
+// NewPlanSubscription returns the NewSubscription subscribing payerEmail to
+// the plan planID, charging the card of cardTokenID.
+func NewPlanSubscription(planID string, payerEmail string, cardTokenID string) NewSubscription {
+	return NewSubscription{
+		PreapprovalPlanID: planID,
+		PayerEmail:        payerEmail,
+		CardTokenID:       cardTokenID,
+		Status:            SubscriptionStatusAuthorized,
+	}
+}
+
+// SubscriptionUpdate is the body of UpdateSubscription; only the fields set
+// are changed. The amount is changed through AutoRecurring.TransactionAmount
+// and the card through CardTokenID.
+type SubscriptionUpdate struct {
+	Reason            string         `json:"reason,omitempty"`
+	ExternalReference string         `json:"external_reference,omitempty"`
+	CardTokenID       string         `json:"card_token_id,omitempty"`
+	AutoRecurring     *AutoRecurring `json:"auto_recurring,omitempty"`
+	BackURL           string         `json:"back_url,omitempty"`
+	Status            string         `json:"status,omitempty"`
+}
+
+func (g *Gateway) CreateSubscription(accessToken string, subscription NewSubscription) (SubscriptionResult, error) {
+	return g.CreateSubscriptionWithContext(context.Background(), accessToken, subscription)
+}
+
+func (g *Gateway) CreateSubscriptionWithContext(ctx context.Context, accessToken string, subscription NewSubscription) (created SubscriptionResult, err error) {
+	err = g.doJSON(ctx, http.MethodPost, "/preapproval", accessToken, subscription, &created)
+	return
+}
+
+func (g *Gateway) UpdateSubscription(accessToken string, subscriptionID string, update SubscriptionUpdate) (SubscriptionResult, error) {
+	return g.UpdateSubscriptionWithContext(context.Background(), accessToken, subscriptionID, update)
+}
+
+func (g *Gateway) UpdateSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string, update SubscriptionUpdate) (updated SubscriptionResult, err error) {
+	err = g.doJSON(ctx, http.MethodPut, "/preapproval/"+subscriptionID, accessToken, update, &updated)
+	return
+}
+
+// PauseSubscription stops charging a subscription until it is reactivated.
+func (g *Gateway) PauseSubscription(accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return g.PauseSubscriptionWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (g *Gateway) PauseSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return g.UpdateSubscriptionWithContext(ctx, accessToken, subscriptionID, SubscriptionUpdate{Status: SubscriptionStatusPaused})
+}
+
+// ReactivateSubscription resumes charging a paused subscription.
+func (g *Gateway) ReactivateSubscription(accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return g.ReactivateSubscriptionWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (g *Gateway) ReactivateSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return g.UpdateSubscriptionWithContext(ctx, accessToken, subscriptionID, SubscriptionUpdate{Status: SubscriptionStatusAuthorized})
+}
+
+// CancelSubscription cancels a subscription for good; cancelled subscriptions
+// can't be reactivated.
+func (g *Gateway) CancelSubscription(accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return g.CancelSubscriptionWithContext(context.Background(), accessToken, subscriptionID)
+}
+
+func (g *Gateway) CancelSubscriptionWithContext(ctx context.Context, accessToken string, subscriptionID string) (SubscriptionResult, error) {
+	return g.UpdateSubscriptionWithContext(ctx, accessToken, subscriptionID, SubscriptionUpdate{Status: SubscriptionStatusCancelled})
+}
+
+// SubscriptionSearch filters a subscriptions search. Empty fields are left
+// out of the query.
+type SubscriptionSearch struct {
+	// Q matches the reason, external reference and payer of the subscriptions.
+	Q                 string
+	Status            string
+	PayerEmail        string
+	PayerID           int64
+	PreapprovalPlanID string
+	ExternalReference string
+	// Range is the date field, e.g. date_created or last_modified, that
+	// BeginDate and EndDate apply to. The dates take either ISO 8601 times or
+	// relative ones such as NOW-30DAYS.
+	Range     string
+	BeginDate string
+	EndDate   string
+	// Sort orders the results, e.g. date_created:desc.
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+func (s SubscriptionSearch) query() string {
+	q := url.Values{}
+	set := func(key string, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("q", s.Q)
+	set("status", s.Status)
+	set("payer_email", s.PayerEmail)
+	if s.PayerID != 0 {
+		q.Set("payer_id", strconv.FormatInt(s.PayerID, 10))
+	}
+	set("preapproval_plan_id", s.PreapprovalPlanID)
+	set("external_reference", s.ExternalReference)
+	set("range", s.Range)
+	set("begin_date", s.BeginDate)
+	set("end_date", s.EndDate)
+	set("sort", s.Sort)
+	if s.Limit > 0 {
+		q.Set("limit", strconv.Itoa(s.Limit))
+	}
+	if s.Offset > 0 {
+		q.Set("offset", strconv.Itoa(s.Offset))
+	}
+	return q.Encode()
+}
+
+func (g *Gateway) SearchSubscriptions(accessToken string, search SubscriptionSearch) (SubscriptionSearchResponse, error) {
+	return g.SearchSubscriptionsWithContext(context.Background(), accessToken, search)
+}
+
+func (g *Gateway) SearchSubscriptionsWithContext(ctx context.Context, accessToken string, search SubscriptionSearch) (subscriptions SubscriptionSearchResponse, err error) {
+	err = g.doJSON(ctx, http.MethodGet, "/preapproval/search?"+search.query(), accessToken, nil, &subscriptions)
+	return
+}
+
+// SubscriptionIterator returns an iterator over every subscription matching
+// search, starting at search.Offset.
+func (g *Gateway) SubscriptionIterator(accessToken string, search SubscriptionSearch) *SubscriptionIterator {
+	return newSubscriptionIterator(search, func(ctx context.Context, search SubscriptionSearch) (SubscriptionSearchResponse, error) {
+		return g.SearchSubscriptionsWithContext(ctx, accessToken, search)
+	})
+}
+
+// SubscriptionIterator walks the pages of a subscriptions search, fetching
+// the next one when the current one is used up:
+//
+//	it := g.SubscriptionIterator(accessToken, search)
+//	for it.Next(ctx) {
+//		subscription := it.Subscription()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type SubscriptionIterator struct {
+	pager
+	page    []SubscriptionResult
+	current SubscriptionResult
+}
+
+func newSubscriptionIterator(search SubscriptionSearch, fetch func(ctx context.Context, search SubscriptionSearch) (SubscriptionSearchResponse, error)) *SubscriptionIterator {
+	it := &SubscriptionIterator{}
+	it.pager = pager{
+		offset: search.Offset,
+		fetch: func(ctx context.Context, offset int) (int, int, error) {
+			search.Offset = offset
+			resp, err := fetch(ctx, search)
+			if err != nil {
+				return 0, 0, err
+			}
+			it.page = resp.Results
+			return len(resp.Results), resp.Paging.Total, nil
+		},
+	}
+	return it
+}
+
+// Next advances to the next subscription, reporting false once all of them
+// were read or a page couldn't be fetched.
+func (it *SubscriptionIterator) Next(ctx context.Context) bool {
+	i, ok := it.pager.next(ctx)
+	if ok {
+		it.current = it.page[i]
+	}
+	return ok
+}
+
+// Subscription returns the subscription Next advanced to.
+func (it *SubscriptionIterator) Subscription() SubscriptionResult {
+	return it.current
+}
@@ -0,0 +1,317 @@
+package mercadopago
+
+import (
+    "bytes"
+    "context"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+)
+
+func TestGateway_CreateSubscription(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "2c938084726fca480172750000000000", "status": "pending", "reason": "Gym", "init_point": "https://www.mercadopago.com.br/subscriptions/checkout?preapproval_id=2c938084726fca480172750000000000", "auto_recurring": {"frequency": 1, "frequency_type": "months", "transaction_amount": 10, "currency_id": "BRL"}}`))),
+    }
+    // When
+    subscription, err := g.CreateSubscription("MY_ACCESS_TOKEN", NewSubscription{
+        Reason:            "Gym",
+        ExternalReference: "USER_1",
+        PayerEmail:        "test_user@testuser.com",
+        BackURL:           "https://example.com/back",
+        AutoRecurring: &AutoRecurring{
+            Frequency:         1,
+            FrequencyType:     "months",
+            TransactionAmount: 10,
+            CurrencyID:        "BRL",
+        },
+        Status: SubscriptionStatusPending,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "2c938084726fca480172750000000000", subscription.ID)
+    require.Equal(t, SubscriptionStatusPending, subscription.Status)
+    require.NotEmpty(t, subscription.InitPoint)
+    require.Equal(t, http.MethodPost, c.req.Method)
+    require.Equal(t, "/preapproval", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"reason": "Gym", "external_reference": "USER_1", "payer_email": "test_user@testuser.com", "back_url": "https://example.com/back", "auto_recurring": {"frequency": 1, "frequency_type": "months", "transaction_amount": 10, "currency_id": "BRL"}, "status": "pending"}`, string(b))
+}
+
+func TestGateway_CreateSubscription_WithPlan(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "SUBSCRIPTION_ID", "status": "authorized"}`))),
+    }
+    // When
+    subscription, err := g.CreateSubscription("MY_ACCESS_TOKEN", NewSubscription{
+        PreapprovalPlanID: "PLAN_ID",
+        PayerEmail:        "test_user@testuser.com",
+        CardTokenID:       "CARD_TOKEN",
+        Status:            SubscriptionStatusAuthorized,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, SubscriptionStatusAuthorized, subscription.Status)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"preapproval_plan_id": "PLAN_ID", "payer_email": "test_user@testuser.com", "card_token_id": "CARD_TOKEN", "status": "authorized"}`, string(b))
+}
+
+func TestGateway_UpdateSubscription(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "SUBSCRIPTION_ID", "status": "authorized", "auto_recurring": {"transaction_amount": 20, "currency_id": "BRL"}}`))),
+    }
+    // When
+    subscription, err := g.UpdateSubscription("MY_ACCESS_TOKEN", "SUBSCRIPTION_ID", SubscriptionUpdate{
+        CardTokenID: "NEW_CARD_TOKEN",
+        AutoRecurring: &AutoRecurring{
+            TransactionAmount: 20,
+            CurrencyID:        "BRL",
+        },
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, float64(20), subscription.AutoRecurring.TransactionAmount)
+    require.Equal(t, http.MethodPut, c.req.Method)
+    require.Equal(t, "/preapproval/SUBSCRIPTION_ID", c.req.URL.Path)
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"card_token_id": "NEW_CARD_TOKEN", "auto_recurring": {"transaction_amount": 20, "currency_id": "BRL"}}`, string(b))
+}
+
+func TestGateway_SubscriptionStatusChanges(t *testing.T) {
+    tt := []struct{
+        name string
+        change func(g *Gateway) (SubscriptionResult, error)
+        wantStatus string
+    }{
+        {
+            name: "pause",
+            change: func(g *Gateway) (SubscriptionResult, error) {
+                return g.PauseSubscription("MY_ACCESS_TOKEN", "SUBSCRIPTION_ID")
+            },
+            wantStatus: SubscriptionStatusPaused,
+        },
+        {
+            name: "reactivate",
+            change: func(g *Gateway) (SubscriptionResult, error) {
+                return g.ReactivateSubscription("MY_ACCESS_TOKEN", "SUBSCRIPTION_ID")
+            },
+            wantStatus: SubscriptionStatusAuthorized,
+        },
+        {
+            name: "cancel",
+            change: func(g *Gateway) (SubscriptionResult, error) {
+                return g.CancelSubscription("MY_ACCESS_TOKEN", "SUBSCRIPTION_ID")
+            },
+            wantStatus: SubscriptionStatusCancelled,
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // Given
+            c := &ClientStub{}
+            g := &Gateway{Client: c}
+            c.resp = &http.Response{
+                Status:     "200",
+                StatusCode: 200,
+                Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": "SUBSCRIPTION_ID", "status": "` + tc.wantStatus + `"}`))),
+            }
+            // When
+            subscription, err := tc.change(g)
+
+            // Then
+            require.NoError(t, err)
+            require.Equal(t, tc.wantStatus, subscription.Status)
+            require.Equal(t, http.MethodPut, c.req.Method)
+            require.Equal(t, "/preapproval/SUBSCRIPTION_ID", c.req.URL.Path)
+
+            b, err := ioutil.ReadAll(c.req.Body)
+            require.NoError(t, err)
+            require.JSONEq(t, `{"status": "`+tc.wantStatus+`"}`, string(b))
+        })
+    }
+}
+
+func TestGateway_CancelSubscription_MercadoPagoError(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "400",
+        StatusCode: 400,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message": "You can not modify a cancelled preapproval", "error": "bad_request", "status": 400}`))),
+    }
+    // When
+    _, err := g.CancelSubscription("MY_ACCESS_TOKEN", "SUBSCRIPTION_ID")
+
+    // Then
+    require.Error(t, err)
+    require.True(t, IsValidation(err))
+    require.Equal(t, "You can not modify a cancelled preapproval", err.Error())
+}
+
+func TestNewPlanSubscription(t *testing.T) {
+    // When
+    subscription := NewPlanSubscription("PLAN_ID", "test_user@testuser.com", "CARD_TOKEN")
+
+    // Then
+    require.Equal(t, NewSubscription{
+        PreapprovalPlanID: "PLAN_ID",
+        PayerEmail:        "test_user@testuser.com",
+        CardTokenID:       "CARD_TOKEN",
+        Status:            SubscriptionStatusAuthorized,
+    }, subscription)
+}
+
+func TestGateway_SearchSubscriptions(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"offset": 0, "limit": 10, "total": 1}, "results": [{"id": "SUBSCRIPTION_ID", "status": "authorized", "preapproval_plan_id": "PLAN_ID"}]}`))),
+    }
+    // When
+    subscriptions, err := g.SearchSubscriptions("MY_ACCESS_TOKEN", SubscriptionSearch{
+        Status:            SubscriptionStatusAuthorized,
+        PayerEmail:        "test_user+1@testuser.com",
+        PayerID:           123456,
+        PreapprovalPlanID: "PLAN_ID",
+        Range:             "date_created",
+        BeginDate:         "NOW-30DAYS",
+        EndDate:           "NOW",
+        Sort:              "date_created:asc",
+        Limit:             10,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, 1, subscriptions.Paging.Total)
+    require.Equal(t, "PLAN_ID", subscriptions.Results[0].PreapprovalPlanID)
+    require.Equal(t, "/preapproval/search", c.req.URL.Path)
+
+    q := c.req.URL.Query()
+    require.Equal(t, "authorized", q.Get("status"))
+    require.Equal(t, "test_user+1@testuser.com", q.Get("payer_email"))
+    require.Equal(t, "123456", q.Get("payer_id"))
+    require.Equal(t, "PLAN_ID", q.Get("preapproval_plan_id"))
+    require.Equal(t, "date_created", q.Get("range"))
+    require.Equal(t, "NOW-30DAYS", q.Get("begin_date"))
+    require.Equal(t, "NOW", q.Get("end_date"))
+    require.Equal(t, "date_created:asc", q.Get("sort"))
+    require.Equal(t, "10", q.Get("limit"))
+    require.Empty(t, q.Get("offset"))
+    require.Empty(t, q.Get("q"))
+}
+
+func TestGateway_GetSubscriptionsSearch(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "200",
+        StatusCode: 200,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"paging": {"total": 0}, "results": []}`))),
+    }
+    // When
+    _, err := g.GetSubscriptionsSearch("MY_ACCESS_TOKEN", "USER 1")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "USER 1", c.req.URL.Query().Get("q"))
+    require.Equal(t, "date_created:desc", c.req.URL.Query().Get("sort"))
+}
+
+func TestSubscriptionIterator(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, `{"paging": {"offset": 0, "limit": 2, "total": 3}, "results": [{"id": "S1"}, {"id": "S2"}]}`),
+            newResponse(http.StatusOK, `{"paging": {"offset": 2, "limit": 2, "total": 3}, "results": [{"id": "S3"}]}`),
+        },
+    }
+    g := &Gateway{Client: c}
+    it := g.SubscriptionIterator("MY_ACCESS_TOKEN", SubscriptionSearch{Status: SubscriptionStatusAuthorized, Limit: 2})
+
+    // When
+    var ids []string
+    for it.Next(context.Background()) {
+        ids = append(ids, it.Subscription().ID)
+    }
+
+    // Then
+    require.NoError(t, it.Err())
+    require.Equal(t, []string{"S1", "S2", "S3"}, ids)
+    require.Equal(t, 3, it.Total())
+    require.Equal(t, 2, c.calls)
+    require.Equal(t, "2", c.reqs[1].URL.Query().Get("offset"))
+    require.False(t, it.Next(context.Background()))
+    require.Equal(t, 2, c.calls)
+}
+
+func TestSubscriptionIterator_Error(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, `{"paging": {"offset": 0, "limit": 1, "total": 2}, "results": [{"id": "S1"}]}`),
+            newResponse(http.StatusInternalServerError, `{"message": "internal server error"}`),
+        },
+    }
+    g := &Gateway{Client: c}
+    it := g.SubscriptionIterator("MY_ACCESS_TOKEN", SubscriptionSearch{Limit: 1})
+
+    // When
+    var ids []string
+    for it.Next(context.Background()) {
+        ids = append(ids, it.Subscription().ID)
+    }
+
+    // Then
+    require.Equal(t, []string{"S1"}, ids)
+    require.EqualError(t, it.Err(), "internal server error")
+}
+
+func TestController_SubscriptionIterator(t *testing.T) {
+    // Given
+    c := &SequenceClientStub{
+        resps: []*http.Response{
+            newResponse(http.StatusOK, `{"paging": {"offset": 0, "limit": 20, "total": 1}, "results": [{"id": "S1"}]}`),
+        },
+    }
+    s := NewControllerWithTokenSource(&Gateway{Client: c}, StaticTokenSource("SOURCE_TOKEN"))
+    it := s.SubscriptionIterator("", SubscriptionSearch{})
+
+    // When
+    require.True(t, it.Next(context.Background()))
+    require.False(t, it.Next(context.Background()))
+
+    // Then
+    require.NoError(t, it.Err())
+    require.Equal(t, "S1", it.Subscription().ID)
+    require.Equal(t, "Bearer SOURCE_TOKEN", c.reqs[0].Header.Get("Authorization"))
+}
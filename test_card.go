@@ -0,0 +1,91 @@
+package mercadopago
+
+// Cardholder names of test cards forcing the outcome of sandbox payments.
+const (
+	TestCardApproved          = "APRO"
+	TestCardRejectedOther     = "OTHE"
+	TestCardPending           = "CONT"
+	TestCardCallForAuthorize  = "CALL"
+	TestCardInsufficientFunds = "FUND"
+	TestCardBadSecurityCode   = "SECU"
+	TestCardBadExpirationDate = "EXPI"
+	TestCardBadFilledForm     = "FORM"
+)
+
+// TestCard is one of the sandbox cards MercadoPago documents for a site.
+type TestCard struct {
+	Site_id           string
+	Payment_method_id string
+	Payment_type_id   string
+	Card_number       string
+	Security_code     string
+	Expiration_month  int
+	Expiration_year   int
+}
+
+var _testCards = []TestCard{
+	{"MLA", "master", "credit_card", "5031755734530604", "123", 11, 2030},
+	{"MLA", "visa", "credit_card", "4509953566233704", "123", 11, 2030},
+	{"MLA", "amex", "credit_card", "371180303257522", "1234", 11, 2030},
+	{"MLA", "debvisa", "debit_card", "4002768694395619", "123", 11, 2030},
+	{"MLA", "debmaster", "debit_card", "5287338310253304", "123", 11, 2030},
+	{"MLB", "master", "credit_card", "5031433215406351", "123", 11, 2030},
+	{"MLB", "visa", "credit_card", "4235647728025682", "123", 11, 2030},
+	{"MLB", "amex", "credit_card", "375365153556885", "1234", 11, 2030},
+	{"MLB", "debelo", "debit_card", "5067766783888311", "123", 11, 2030},
+	{"MLC", "master", "credit_card", "5416752602582580", "123", 11, 2030},
+	{"MLC", "visa", "credit_card", "4168818844447115", "123", 11, 2030},
+	{"MLC", "amex", "credit_card", "375778174461804", "1234", 11, 2030},
+	{"MLC", "debmaster", "debit_card", "5241019826646950", "123", 11, 2030},
+	{"MLC", "debvisa", "debit_card", "4023653523914373", "123", 11, 2030},
+	{"MCO", "master", "credit_card", "5254133674403564", "123", 11, 2030},
+	{"MCO", "visa", "credit_card", "4013540682746260", "123", 11, 2030},
+	{"MCO", "amex", "credit_card", "374378187755283", "1234", 11, 2030},
+	{"MCO", "debvisa", "debit_card", "4915112055246507", "123", 11, 2030},
+	{"MLM", "master", "credit_card", "5474925432670366", "123", 11, 2030},
+	{"MLM", "visa", "credit_card", "4075595716483764", "123", 11, 2030},
+	{"MLM", "debmaster", "debit_card", "5579053461482647", "123", 11, 2030},
+	{"MLM", "debvisa", "debit_card", "4189141221267633", "123", 11, 2030},
+	{"MPE", "master", "credit_card", "5031755734530604", "123", 11, 2030},
+	{"MPE", "visa", "credit_card", "4009175332806176", "123", 11, 2030},
+	{"MPE", "amex", "credit_card", "371180303257522", "1234", 11, 2030},
+	{"MLU", "master", "credit_card", "5808887774641586", "123", 11, 2030},
+	{"MLU", "visa", "credit_card", "4157236211736486", "123", 11, 2030},
+}
+
+// TestCards returns the sandbox cards of a site, e.g. MLB for Brazil.
+func TestCards(siteID string) []TestCard {
+	var cards []TestCard
+	for _, c := range _testCards {
+		if c.Site_id == siteID {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// FindTestCard returns the sandbox card of a site and payment method, e.g.
+// MLB and visa.
+func FindTestCard(siteID string, paymentMethodID string) (TestCard, bool) {
+	for _, c := range _testCards {
+		if c.Site_id == siteID && c.Payment_method_id == paymentMethodID {
+			return c, true
+		}
+	}
+	return TestCard{}, false
+}
+
+// NewCardToken returns the NewCardToken of the card held by outcome, one of
+// the TestCard cardholder names, so the payments made with it end that way.
+func (c TestCard) NewCardToken(outcome string, identification Identification) NewCardToken {
+	return NewCardToken{
+		Card_number:      c.Card_number,
+		Expiration_month: c.Expiration_month,
+		Expiration_year:  c.Expiration_year,
+		Security_code:    c.Security_code,
+		Cardholder: &Cardholder{
+			Name:           outcome,
+			Identification: identification,
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package mercadopago
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Offline payment methods, paid with a ticket at a bank, store or ATM.
+const (
+	PaymentMethodBoleto       = "bolbradesco"
+	PaymentMethodPEC          = "pec"
+	PaymentMethodOXXO         = "oxxo"
+	PaymentMethodRapipago     = "rapipago"
+	PaymentMethodPagoFacil    = "pagofacil"
+	PaymentMethodPagoEfectivo = "pagoefectivo_atm"
+)
+
+// NewTicketPayment is the body of CreateTicketPayment.
+type NewTicketPayment struct {
+	// Payment_method_id is one of the offline payment methods, e.g.
+	// PaymentMethodBoleto.
+	Payment_method_id  string
+	Transaction_amount float64
+	Description        string
+	// Payer must have an identification and an address for boletos.
+	Payer              PaymentPayer
+	External_reference string
+	Notification_url   string
+	Metadata           map[string]interface{}
+	// Date_of_expiration is the due date of the ticket. The zero time keeps
+	// the default of the payment method.
+	Date_of_expiration time.Time
+}
+
+func (p NewTicketPayment) payment() (NewPayment, error) {
+	if p.Payment_method_id == "" {
+		return NewPayment{}, fmt.Errorf("payment method is required")
+	}
+
+	if p.Payment_method_id == PaymentMethodBoleto {
+		if p.Payer.Identification.Number == "" {
+			return NewPayment{}, fmt.Errorf("boleto payer identification is required")
+		}
+		if p.Payer.Address == nil || p.Payer.Address.Zip_code == "" {
+			return NewPayment{}, fmt.Errorf("boleto payer address is required")
+		}
+	}
+
+	payment := NewPayment{
+		Transaction_amount: p.Transaction_amount,
+		Description:        p.Description,
+		Payment_method_id:  p.Payment_method_id,
+		Payer:              p.Payer,
+		External_reference: p.External_reference,
+		Notification_url:   p.Notification_url,
+		Metadata:           p.Metadata,
+	}
+	if !p.Date_of_expiration.IsZero() {
+		payment.Date_of_expiration = p.Date_of_expiration.Format(_dateLayout)
+	}
+
+	return payment, nil
+}
+
+// TicketPayment is an offline payment waiting to be paid with its ticket.
+type TicketPayment struct {
+	Payment Payment
+	// Ticket_url is the ticket to print or show, e.g. the boleto PDF.
+	Ticket_url string
+	// Barcode is the barcode content of the ticket, and Digitable_line the
+	// typeable line of boletos.
+	Barcode                     string
+	Digitable_line              string
+	Payment_method_reference_id string
+	// Expiration is the due date of the ticket; it is zero if MercadoPago
+	// didn't send it.
+	Expiration time.Time
+}
+
+func newTicketPayment(payment Payment) TicketPayment {
+	details := payment.Transaction_details
+
+	barcode := payment.Barcode.Content
+	if barcode == "" {
+		barcode = details.Barcode.Content
+	}
+
+	return TicketPayment{
+		Payment:                     payment,
+		Ticket_url:                  details.External_resource_url,
+		Barcode:                     barcode,
+		Digitable_line:              details.Digitable_line,
+		Payment_method_reference_id: details.Payment_method_reference_id,
+		Expiration:                  payment.Expiration(),
+	}
+}
+
+// CreateTicketPayment creates an offline payment, such as a boleto or an
+// OXXO payment, and returns its ticket. The payment stays pending with the
+// StatusDetailPendingWaitingPayment detail until the ticket is paid.
+func (g *Gateway) CreateTicketPayment(accessToken string, ticket NewTicketPayment) (TicketPayment, error) {
+	return g.CreateTicketPaymentWithContext(context.Background(), accessToken, ticket)
+}
+
+func (g *Gateway) CreateTicketPaymentWithContext(ctx context.Context, accessToken string, ticket NewTicketPayment) (TicketPayment, error) {
+	payment, err := ticket.payment()
+	if err != nil {
+		return TicketPayment{}, err
+	}
+
+	created, err := g.CreatePaymentWithContext(ctx, accessToken, payment)
+	if err != nil {
+		return TicketPayment{}, err
+	}
+
+	return newTicketPayment(created), nil
+}
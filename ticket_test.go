@@ -0,0 +1,156 @@
+package mercadopago
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "testing"
+    "time"
+)
+
+func TestGateway_CreateTicketPayment_Boleto(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "pending", "status_detail": "pending_waiting_payment", "payment_method_id": "bolbradesco", "payment_type_id": "ticket", "date_of_expiration": "2030-01-10T22:59:59.000-04:00", "barcode": {"content": "23791924500000100003380260600155440500006330"}, "transaction_details": {"external_resource_url": "https://www.mercadopago.com.br/payments/123/ticket", "digitable_line": "23793380296060015544105000063301192450000010000", "payment_method_reference_id": "6001554405"}}`))),
+    }
+    expiration := time.Date(2030, 1, 10, 22, 59, 59, 0, time.FixedZone("", -4*60*60))
+
+    // When
+    ticket, err := g.CreateTicketPayment("MY_ACCESS_TOKEN", NewTicketPayment{
+        Payment_method_id:  PaymentMethodBoleto,
+        Transaction_amount: 100,
+        Description:        "Pro plan",
+        Payer: PaymentPayer{
+            Email:          "test_user@testuser.com",
+            First_name:     "Test",
+            Last_name:      "User",
+            Identification: Identification{Type: "CPF", Number: "19119119100"},
+            Address: &PaymentPayerAddress{
+                Zip_code:      "06233200",
+                Street_name:   "Av. das Nações Unidas",
+                Street_number: "3003",
+                Neighborhood:  "Bonfim",
+                City:          "Osasco",
+                Federal_unit:  "SP",
+            },
+        },
+        Date_of_expiration: expiration,
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "https://www.mercadopago.com.br/payments/123/ticket", ticket.Ticket_url)
+    require.Equal(t, "23791924500000100003380260600155440500006330", ticket.Barcode)
+    require.Equal(t, "23793380296060015544105000063301192450000010000", ticket.Digitable_line)
+    require.Equal(t, "6001554405", ticket.Payment_method_reference_id)
+    require.True(t, expiration.Equal(ticket.Expiration))
+    require.True(t, ticket.Payment.AwaitingPayment())
+
+    b, err := ioutil.ReadAll(c.req.Body)
+    require.NoError(t, err)
+    require.JSONEq(t, `{
+        "transaction_amount": 100,
+        "description": "Pro plan",
+        "payment_method_id": "bolbradesco",
+        "date_of_expiration": "2030-01-10T22:59:59.000-04:00",
+        "payer": {
+            "email": "test_user@testuser.com",
+            "first_name": "Test",
+            "last_name": "User",
+            "identification": {"type": "CPF", "number": "19119119100"},
+            "address": {"zip_code": "06233200", "street_name": "Av. das Nações Unidas", "street_number": "3003", "neighborhood": "Bonfim", "city": "Osasco", "federal_unit": "SP"}
+        }
+    }`, string(b))
+}
+
+func TestGateway_CreateTicketPayment_OXXO(t *testing.T) {
+    // Given
+    c := &ClientStub{}
+    g := &Gateway{Client: c}
+    c.resp = &http.Response{
+        Status:     "201",
+        StatusCode: 201,
+        Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"id": 123, "status": "pending", "status_detail": "pending_waiting_payment", "transaction_details": {"external_resource_url": "https://www.mercadopago.com.mx/payments/123/ticket", "barcode": {"content": "98000000123"}}}`))),
+    }
+    // When
+    ticket, err := g.CreateTicketPayment("MY_ACCESS_TOKEN", NewTicketPayment{
+        Payment_method_id:  PaymentMethodOXXO,
+        Transaction_amount: 100,
+        Payer:              PaymentPayer{Email: "test_user@testuser.com"},
+    })
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "98000000123", ticket.Barcode)
+    require.True(t, ticket.Expiration.IsZero())
+}
+
+func TestGateway_CreateTicketPayment_Error(t *testing.T) {
+    tt := []struct{
+        name string
+        ticket NewTicketPayment
+        wantError string
+    }{
+        {
+            name: "missing payment method",
+            ticket: NewTicketPayment{Transaction_amount: 100},
+            wantError: "payment method is required",
+        },
+        {
+            name: "boleto without identification",
+            ticket: NewTicketPayment{Payment_method_id: PaymentMethodBoleto, Transaction_amount: 100},
+            wantError: "boleto payer identification is required",
+        },
+        {
+            name: "boleto without address",
+            ticket: NewTicketPayment{
+                Payment_method_id: PaymentMethodBoleto,
+                Transaction_amount: 100,
+                Payer: PaymentPayer{Identification: Identification{Type: "CPF", Number: "19119119100"}},
+            },
+            wantError: "boleto payer address is required",
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // Given
+            c := &ClientStub{}
+            g := &Gateway{Client: c}
+
+            // When
+            _, err := g.CreateTicketPayment("MY_ACCESS_TOKEN", tc.ticket)
+
+            // Then
+            require.EqualError(t, err, tc.wantError)
+            require.Nil(t, c.req)
+        })
+    }
+}
+
+func TestPayment_Status(t *testing.T) {
+    tt := []struct{
+        name string
+        payment Payment
+        wantAwaitingPayment bool
+        wantExpired bool
+    }{
+        {name: "waiting payment", payment: Payment{Status: PaymentStatusPending, Status_detail: StatusDetailPendingWaitingPayment}, wantAwaitingPayment: true},
+        {name: "waiting transfer", payment: Payment{Status: PaymentStatusPending, Status_detail: StatusDetailPendingWaitingTransfer}, wantAwaitingPayment: true},
+        {name: "pending review", payment: Payment{Status: PaymentStatusPending, Status_detail: "pending_review_manual"}},
+        {name: "expired", payment: Payment{Status: PaymentStatusCancelled, Status_detail: StatusDetailExpired}, wantExpired: true},
+        {name: "approved", payment: Payment{Status: PaymentStatusApproved, Status_detail: "accredited"}},
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            require.Equal(t, tc.wantAwaitingPayment, tc.payment.AwaitingPayment())
+            require.Equal(t, tc.wantExpired, tc.payment.Expired())
+        })
+    }
+}
@@ -0,0 +1,141 @@
+package mercadopago
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	_defaultTokenRefreshBefore  = 5 * time.Minute
+	_defaultTokenRefreshTimeout = 30 * time.Second
+)
+
+// Token is an OAuth token issued by /oauth/token.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+	UserID       int64  `json:"user_id"`
+	RefreshToken string `json:"refresh_token"`
+	PublicKey    string `json:"public_key"`
+	LiveMode     bool   `json:"live_mode"`
+	// Expiry is computed from ExpiresIn when the token is received. A zero
+	// Expiry means the token doesn't expire.
+	Expiry time.Time `json:"-"`
+}
+
+// validFor reports whether the token is still valid d from now.
+func (t Token) validFor(d time.Duration) bool {
+	if t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(d).Before(t.Expiry)
+}
+
+// TokenSource supplies access tokens to Controller.
+type TokenSource interface {
+	Token(ctx context.Context) (Token, error)
+}
+
+// StaticTokenSource always returns the same access token.
+type StaticTokenSource string
+
+func (s StaticTokenSource) Token(_ context.Context) (Token, error) {
+	return Token{AccessToken: string(s)}, nil
+}
+
+// ClientCredentialsTokenSource caches a client_credentials token and requests
+// a new one RefreshBefore its expiry. Concurrent callers share a single
+// in-flight request to /oauth/token.
+type ClientCredentialsTokenSource struct {
+	Client      ClientGateway
+	Credentials Credentials
+	// RefreshBefore is how long before expiry the token is renewed; it
+	// defaults to five minutes.
+	RefreshBefore time.Duration
+	// RefreshTimeout bounds each request for a new token; it defaults to 30
+	// seconds.
+	RefreshTimeout time.Duration
+
+	mu       sync.Mutex
+	token    Token
+	inflight *tokenCall
+}
+
+type tokenCall struct {
+	done  chan struct{}
+	token Token
+	err   error
+}
+
+func NewClientCredentialsTokenSource(client ClientGateway, credentials Credentials) *ClientCredentialsTokenSource {
+	return &ClientCredentialsTokenSource{
+		Client:      client,
+		Credentials: credentials,
+	}
+}
+
+func (s *ClientCredentialsTokenSource) Token(ctx context.Context) (Token, error) {
+	refreshBefore := s.RefreshBefore
+	if refreshBefore <= 0 {
+		refreshBefore = _defaultTokenRefreshBefore
+	}
+
+	s.mu.Lock()
+	if s.token.validFor(refreshBefore) {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+
+	call := s.inflight
+	if call == nil {
+		call = &tokenCall{done: make(chan struct{})}
+		s.inflight = call
+		// The request isn't bound to ctx, since other callers may be waiting on
+		// it, but to RefreshTimeout.
+		go s.refresh(call)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return Token{}, ctx.Err()
+	case <-call.done:
+	}
+
+	if call.err != nil {
+		// Keep serving the cached token while it hasn't actually expired.
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.token.validFor(0) {
+			return s.token, nil
+		}
+		return Token{}, call.err
+	}
+
+	return call.token, nil
+}
+
+func (s *ClientCredentialsTokenSource) refresh(call *tokenCall) {
+	timeout := s.RefreshTimeout
+	if timeout <= 0 {
+		timeout = _defaultTokenRefreshTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	call.token, call.err = s.Client.GetTokenWithContext(ctx, s.Credentials)
+
+	s.mu.Lock()
+	if call.err == nil {
+		s.token = call.token
+	}
+	s.inflight = nil
+	s.mu.Unlock()
+
+	close(call.done)
+}
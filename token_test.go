@@ -0,0 +1,123 @@
+package mercadopago
+
+import (
+    "context"
+    "fmt"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestGateway_GetToken(t *testing.T) {
+    // Given
+    c := &ClientStub{resp: newResponse(http.StatusOK, `{"access_token": "APP_USR-1234", "token_type": "bearer", "expires_in": 21600, "scope": "offline_access read write", "user_id": 987654, "refresh_token": "TG-1234"}`)}
+    g := &Gateway{Client: c}
+
+    // When
+    token, err := g.GetToken(Credentials{ClientID: "ABC123", ClientSecret: "123ABC"})
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "APP_USR-1234", token.AccessToken)
+    require.Equal(t, int64(987654), token.UserID)
+    require.Equal(t, "TG-1234", token.RefreshToken)
+    require.Equal(t, "offline_access read write", token.Scope)
+    require.WithinDuration(t, time.Now().Add(6*time.Hour), token.Expiry, time.Minute)
+}
+
+func TestClientCredentialsTokenSource_CachesToken(t *testing.T) {
+    // Given
+    var calls int32
+    g := &Gateway{Client: clientFunc(func(_ *http.Request) (*http.Response, error) {
+        n := atomic.AddInt32(&calls, 1)
+        time.Sleep(10 * time.Millisecond)
+        return newResponse(http.StatusOK, fmt.Sprintf(`{"access_token": "TOKEN_%d", "expires_in": 21600}`, n)), nil
+    })}
+    ts := NewClientCredentialsTokenSource(g, Credentials{ClientID: "ABC123", ClientSecret: "123ABC"})
+
+    // When
+    var wg sync.WaitGroup
+    tokens := make([]Token, 10)
+    for i := range tokens {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            tokens[i], _ = ts.Token(context.Background())
+        }(i)
+    }
+    wg.Wait()
+
+    token, err := ts.Token(context.Background())
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "TOKEN_1", token.AccessToken)
+    for _, tk := range tokens {
+        require.Equal(t, "TOKEN_1", tk.AccessToken)
+    }
+    require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClientCredentialsTokenSource_RefreshesBeforeExpiry(t *testing.T) {
+    // Given
+    var calls int32
+    g := &Gateway{Client: clientFunc(func(_ *http.Request) (*http.Response, error) {
+        n := atomic.AddInt32(&calls, 1)
+        return newResponse(http.StatusOK, fmt.Sprintf(`{"access_token": "TOKEN_%d", "expires_in": 60}`, n)), nil
+    })}
+    ts := NewClientCredentialsTokenSource(g, Credentials{ClientID: "ABC123", ClientSecret: "123ABC"})
+
+    // When
+    first, err := ts.Token(context.Background())
+    require.NoError(t, err)
+    second, err := ts.Token(context.Background())
+    require.NoError(t, err)
+
+    // Then
+    require.Equal(t, "TOKEN_1", first.AccessToken)
+    require.Equal(t, "TOKEN_2", second.AccessToken)
+}
+
+func TestClientCredentialsTokenSource_Error(t *testing.T) {
+    // Given
+    g := &Gateway{Client: &ClientStub{resp: newResponse(http.StatusUnauthorized, `{"message": "invalid client_id"}`)}}
+    ts := NewClientCredentialsTokenSource(g, Credentials{ClientID: "ABC123", ClientSecret: "123ABC"})
+
+    // When
+    _, err := ts.Token(context.Background())
+
+    // Then
+    require.True(t, IsUnauthorized(err))
+}
+
+func TestClientCredentialsTokenSource_RefreshTimeout(t *testing.T) {
+    // Given
+    g := &Gateway{Client: clientFunc(func(req *http.Request) (*http.Response, error) {
+        <-req.Context().Done()
+        return nil, req.Context().Err()
+    })}
+    ts := NewClientCredentialsTokenSource(g, Credentials{ClientID: "ABC123", ClientSecret: "123ABC"})
+    ts.RefreshTimeout = 10 * time.Millisecond
+
+    // When
+    _, err := ts.Token(context.Background())
+
+    // Then
+    require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestController_UsesTokenSource(t *testing.T) {
+    // Given
+    c := &ClientStub{resp: newResponse(http.StatusOK, `{"id": 123}`)}
+    s := NewControllerWithTokenSource(&Gateway{Client: c}, StaticTokenSource("SOURCE_TOKEN"))
+
+    // When
+    _, err := s.GetPayments("", "123")
+
+    // Then
+    require.NoError(t, err)
+    require.Equal(t, "Bearer SOURCE_TOKEN", c.req.Header.Get("Authorization"))
+}
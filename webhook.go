@@ -0,0 +1,313 @@
+package mercadopago
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification types, sent as "type" by Webhooks and as "topic" by IPN.
+const (
+	EventTypePayment                       = "payment"
+	EventTypeMerchantOrder                 = "merchant_order"
+	EventTypeChargeback                    = "chargebacks"
+	EventTypeSubscriptionPreapproval       = "subscription_preapproval"
+	EventTypeSubscriptionPreapprovalPlan   = "subscription_preapproval_plan"
+	EventTypeSubscriptionAuthorizedPayment = "subscription_authorized_payment"
+	EventTypeMPConnect                     = "mp-connect"
+)
+
+const (
+	_defaultWebhookTolerance = 5 * time.Minute
+	_maxWebhookBodySize      = 1 << 20
+)
+
+// Event is a notification received by WebhookHandler, either in the Webhooks
+// format (type and data.id) or in the legacy IPN one (topic and id).
+type Event struct {
+	// ID is the notification ID; IPN notifications don't have one.
+	ID     string
+	Type   string
+	Action string
+	// DataID is the ID of the resource the notification is about, e.g. the
+	// payment ID for payment notifications.
+	DataID      string
+	LiveMode    bool
+	DateCreated string
+	UserID      string
+	APIVersion  string
+	// IPN tells the notification came in the legacy IPN format.
+	IPN bool
+	// Body is the raw notification body.
+	Body []byte
+}
+
+// EventHandler processes an event. Returning an error makes WebhookHandler
+// answer with a 500 so MercadoPago delivers the notification again.
+type EventHandler func(ctx context.Context, event Event) error
+
+// WebhookHandler is an http.Handler receiving MercadoPago notifications. It
+// verifies the x-signature header against Secret and dispatches the events to
+// the handlers registered with On and OnAny.
+type WebhookHandler struct {
+	// Secret is the webhook signature secret of the application. When empty,
+	// signatures aren't verified.
+	Secret string
+	// Tolerance is the maximum age of a signature timestamp; it defaults to
+	// five minutes.
+	Tolerance time.Duration
+	// AllowUnsigned accepts legacy IPN notifications without an x-signature
+	// header even when Secret is set, as they are not signed. Webhooks
+	// notifications must always be signed.
+	AllowUnsigned bool
+
+	handlers    map[string][]EventHandler
+	anyHandlers []EventHandler
+	now         func() time.Time
+}
+
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{
+		Secret: secret,
+	}
+}
+
+// On registers fn for events of the given type, e.g. EventTypePayment.
+// Handlers must be registered before the WebhookHandler starts serving.
+func (h *WebhookHandler) On(eventType string, fn EventHandler) {
+	if h.handlers == nil {
+		h.handlers = map[string][]EventHandler{}
+	}
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// OnAny registers fn for events of every type.
+func (h *WebhookHandler) OnAny(fn EventHandler) {
+	h.anyHandlers = append(h.anyHandlers, fn)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprintf(w, "method not allowed")
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, _maxWebhookBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "couldn't read body: %v", err)
+		return
+	}
+
+	event, err := parseEvent(r, body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "couldn't parse notification: %v", err)
+		return
+	}
+
+	if err := h.verify(r, event); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, "invalid signature: %v", err)
+		return
+	}
+
+	handlers := append(append([]EventHandler(nil), h.handlers[event.Type]...), h.anyHandlers...)
+	for _, fn := range handlers {
+		if err := fn(r.Context(), event); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "couldn't handle notification: %v", err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func parseEvent(r *http.Request, body []byte) (Event, error) {
+	query := r.URL.Query()
+
+	var n struct {
+		ID          json.RawMessage `json:"id"`
+		Type        string          `json:"type"`
+		Topic       string          `json:"topic"`
+		Resource    string          `json:"resource"`
+		Action      string          `json:"action"`
+		LiveMode    bool            `json:"live_mode"`
+		DateCreated string          `json:"date_created"`
+		UserID      json.RawMessage `json:"user_id"`
+		APIVersion  string          `json:"api_version"`
+		Data        struct {
+			ID json.RawMessage `json:"id"`
+		} `json:"data"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &n); err != nil {
+			return Event{}, err
+		}
+	}
+
+	event := Event{
+		Action:      n.Action,
+		LiveMode:    n.LiveMode,
+		DateCreated: n.DateCreated,
+		UserID:      rawString(n.UserID),
+		APIVersion:  n.APIVersion,
+		Body:        body,
+	}
+
+	if topic := firstNonEmpty(query.Get("topic"), n.Topic); topic != "" {
+		event.IPN = true
+		event.Type = topic
+		event.DataID = query.Get("id")
+		if event.DataID == "" && n.Resource != "" {
+			event.DataID = n.Resource[strings.LastIndex(n.Resource, "/")+1:]
+		}
+	} else {
+		event.ID = rawString(n.ID)
+		event.Type = firstNonEmpty(n.Type, query.Get("type"))
+		event.DataID = firstNonEmpty(query.Get("data.id"), rawString(n.Data.ID))
+	}
+
+	if event.Type == "" {
+		return Event{}, fmt.Errorf("missing notification type")
+	}
+
+	return event, nil
+}
+
+// verify checks the x-signature header, "ts=<timestamp>,v1=<hmac>", where the
+// HMAC-SHA256 is computed over "id:<data.id>;request-id:<x-request-id>;ts:<ts>;".
+// Only the data.id query parameter is signed, so signed events must carry it
+// there and are dispatched with that ID.
+func (h *WebhookHandler) verify(r *http.Request, event Event) error {
+	if h.Secret == "" {
+		return nil
+	}
+
+	header := r.Header.Get("x-signature")
+	if header == "" {
+		if h.AllowUnsigned && event.IPN {
+			return nil
+		}
+		return fmt.Errorf("missing x-signature header")
+	}
+
+	var ts, v1 string
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "ts":
+			ts = kv[1]
+		case "v1":
+			v1 = kv[1]
+		}
+	}
+	if ts == "" || v1 == "" {
+		return fmt.Errorf("malformed x-signature header")
+	}
+
+	dataID := r.URL.Query().Get("data.id")
+	if dataID == "" {
+		return fmt.Errorf("missing data.id query parameter")
+	}
+	if dataID != event.DataID {
+		return fmt.Errorf("data id is not signed")
+	}
+
+	if err := h.checkTimestamp(ts); err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	mac.Write([]byte(signatureManifest(dataID, r.Header.Get("x-request-id"), ts)))
+	expected := mac.Sum(nil)
+
+	got, err := hex.DecodeString(v1)
+	if err != nil || !hmac.Equal(got, expected) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
+
+// signatureManifest builds the signed template, leaving out the parts whose
+// value wasn't sent. Alphanumeric data IDs are signed in lower case.
+func signatureManifest(dataID string, requestID string, ts string) string {
+	var b strings.Builder
+	if dataID != "" {
+		b.WriteString("id:" + strings.ToLower(dataID) + ";")
+	}
+	if requestID != "" {
+		b.WriteString("request-id:" + requestID + ";")
+	}
+	b.WriteString("ts:" + ts + ";")
+	return b.String()
+}
+
+// checkTimestamp rejects signatures older or newer than the tolerance. The
+// timestamp may come in seconds or in milliseconds.
+func (h *WebhookHandler) checkTimestamp(ts string) error {
+	n, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("malformed timestamp")
+	}
+
+	var signedAt time.Time
+	if n > 1e11 {
+		signedAt = time.Unix(0, n*int64(time.Millisecond))
+	} else {
+		signedAt = time.Unix(n, 0)
+	}
+
+	now := time.Now
+	if h.now != nil {
+		now = h.now
+	}
+
+	tolerance := h.Tolerance
+	if tolerance <= 0 {
+		tolerance = _defaultWebhookTolerance
+	}
+
+	if d := now().Sub(signedAt); d > tolerance || d < -tolerance {
+		return fmt.Errorf("stale timestamp")
+	}
+
+	return nil
+}
+
+// rawString returns a JSON string or number as a string.
+func rawString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	return string(raw)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,224 @@
+package mercadopago
+
+import (
+    "bytes"
+    "context"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "strconv"
+    "testing"
+    "time"
+)
+
+func sign(secret string, dataID string, requestID string, ts string) string {
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(fmt.Sprintf("id:%s;request-id:%s;ts:%s;", dataID, requestID, ts)))
+    return fmt.Sprintf("ts=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func newWebhookRequest(target string, body string, signature string) *http.Request {
+    r := httptest.NewRequest(http.MethodPost, target, bytes.NewReader([]byte(body)))
+    r.Header.Set("x-request-id", "REQUEST_ID")
+    if signature != "" {
+        r.Header.Set("x-signature", signature)
+    }
+    return r
+}
+
+func TestWebhookHandler_Webhook(t *testing.T) {
+    // Given
+    var got []Event
+    h := NewWebhookHandler("SECRET")
+    h.On(EventTypePayment, func(_ context.Context, e Event) error {
+        got = append(got, e)
+        return nil
+    })
+    h.On(EventTypeMerchantOrder, func(_ context.Context, e Event) error {
+        t.Fatal("unexpected merchant order event")
+        return nil
+    })
+    ts := strconv.FormatInt(time.Now().Unix(), 10)
+    body := `{"id": 12345, "live_mode": true, "type": "payment", "date_created": "2015-03-25T10:04:58.396-04:00", "user_id": 44444, "api_version": "v1", "action": "payment.created", "data": {"id": "999999999"}}`
+    w := httptest.NewRecorder()
+
+    // When
+    h.ServeHTTP(w, newWebhookRequest("/webhooks?data.id=999999999&type=payment", body, sign("SECRET", "999999999", "REQUEST_ID", ts)))
+
+    // Then
+    require.Equal(t, http.StatusOK, w.Code)
+    require.Len(t, got, 1)
+    require.Equal(t, "12345", got[0].ID)
+    require.Equal(t, EventTypePayment, got[0].Type)
+    require.Equal(t, "payment.created", got[0].Action)
+    require.Equal(t, "999999999", got[0].DataID)
+    require.Equal(t, "44444", got[0].UserID)
+    require.True(t, got[0].LiveMode)
+    require.False(t, got[0].IPN)
+}
+
+func TestWebhookHandler_IPN(t *testing.T) {
+    // Given
+    var got Event
+    h := NewWebhookHandler("SECRET")
+    h.AllowUnsigned = true
+    h.OnAny(func(_ context.Context, e Event) error {
+        got = e
+        return nil
+    })
+    w := httptest.NewRecorder()
+
+    // When
+    h.ServeHTTP(w, newWebhookRequest("/webhooks?topic=merchant_order&id=5678", `{"resource": "https://api.mercadolibre.com/merchant_orders/5678", "topic": "merchant_order"}`, ""))
+
+    // Then
+    require.Equal(t, http.StatusOK, w.Code)
+    require.True(t, got.IPN)
+    require.Equal(t, EventTypeMerchantOrder, got.Type)
+    require.Equal(t, "5678", got.DataID)
+}
+
+func TestWebhookHandler_Error(t *testing.T) {
+    now := time.Now()
+    fresh := strconv.FormatInt(now.Unix(), 10)
+    stale := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+    body := `{"type": "payment", "action": "payment.updated", "data": {"id": "999999999"}}`
+
+    tt := []struct{
+        name string
+        method string
+        target string
+        body string
+        signature string
+        allowUnsigned bool
+        handlerErr error
+        wantError string
+        wantErrorStatusCode int
+    }{
+        {
+            name: "wrong method",
+            method: http.MethodGet,
+            target: "/webhooks?data.id=999999999&type=payment",
+            wantError: "method not allowed",
+            wantErrorStatusCode: http.StatusMethodNotAllowed,
+        },
+        {
+            name: "missing signature",
+            target: "/webhooks?data.id=999999999&type=payment",
+            body: body,
+            wantError: "invalid signature: missing x-signature header",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "unsigned webhook when unsigned IPN is allowed",
+            target: "/webhooks?data.id=999999999&type=payment",
+            body: body,
+            allowUnsigned: true,
+            wantError: "invalid signature: missing x-signature header",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "signed with another secret",
+            target: "/webhooks?data.id=999999999&type=payment",
+            body: body,
+            signature: sign("OTHER_SECRET", "999999999", "REQUEST_ID", fresh),
+            wantError: "invalid signature: signature mismatch",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "signed for another resource",
+            target: "/webhooks?data.id=111111111&type=payment",
+            body: body,
+            signature: sign("SECRET", "999999999", "REQUEST_ID", fresh),
+            wantError: "invalid signature: signature mismatch",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "data id only in the body",
+            target: "/webhooks?type=payment",
+            body: body,
+            signature: sign("SECRET", "", "REQUEST_ID", fresh),
+            wantError: "invalid signature: missing data.id query parameter",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "signed IPN",
+            target: "/webhooks?topic=payment&id=999999999",
+            signature: sign("SECRET", "", "REQUEST_ID", fresh),
+            wantError: "invalid signature: missing data.id query parameter",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "IPN ID differs from the signed data id",
+            target: "/webhooks?topic=payment&id=111111111&data.id=999999999",
+            signature: sign("SECRET", "999999999", "REQUEST_ID", fresh),
+            wantError: "invalid signature: data id is not signed",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "stale timestamp",
+            target: "/webhooks?data.id=999999999&type=payment",
+            body: body,
+            signature: sign("SECRET", "999999999", "REQUEST_ID", stale),
+            wantError: "invalid signature: stale timestamp",
+            wantErrorStatusCode: http.StatusUnauthorized,
+        },
+        {
+            name: "missing type",
+            target: "/webhooks",
+            body: `{"data": {"id": "999999999"}}`,
+            wantError: "couldn't parse notification: missing notification type",
+            wantErrorStatusCode: http.StatusBadRequest,
+        },
+        {
+            name: "handler error",
+            target: "/webhooks?data.id=999999999&type=payment",
+            body: body,
+            signature: sign("SECRET", "999999999", "REQUEST_ID", fresh),
+            handlerErr: errors.New("database down"),
+            wantError: "couldn't handle notification: database down",
+            wantErrorStatusCode: http.StatusInternalServerError,
+        },
+    }
+
+    for _, tc := range tt {
+        t.Run(tc.name, func(t *testing.T) {
+            // Given
+            h := NewWebhookHandler("SECRET")
+            h.AllowUnsigned = tc.allowUnsigned
+            h.On(EventTypePayment, func(_ context.Context, _ Event) error {
+                return tc.handlerErr
+            })
+            r := newWebhookRequest(tc.target, tc.body, tc.signature)
+            if tc.method != "" {
+                r.Method = tc.method
+            }
+            w := httptest.NewRecorder()
+
+            // When
+            h.ServeHTTP(w, r)
+
+            // Then
+            require.Equal(t, tc.wantError, w.Body.String())
+            require.Equal(t, tc.wantErrorStatusCode, w.Code)
+        })
+    }
+}
+
+func TestWebhookHandler_MillisecondTimestamp(t *testing.T) {
+    // Given
+    h := NewWebhookHandler("SECRET")
+    ts := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+    w := httptest.NewRecorder()
+
+    // When
+    h.ServeHTTP(w, newWebhookRequest("/webhooks?data.id=ABC123&type=payment", `{"type": "payment", "data": {"id": "ABC123"}}`, sign("SECRET", "abc123", "REQUEST_ID", ts)))
+
+    // Then
+    require.Equal(t, http.StatusOK, w.Code)
+}